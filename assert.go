@@ -1,6 +1,7 @@
 package assert
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -9,26 +10,70 @@ import (
 	"regexp"
 	"runtime"
 	"runtime/debug"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"text/template"
+	"time"
 )
 
-// Assert wraps the standard testing.T structure.
+// Assert wraps the standard testing.TB interface, so it can be used with
+// testing.T, testing.B and testing.F.
+//
+// An Assert is safe for concurrent use.
 type Assert struct {
-	t     *testing.T
-	stack bool
-	os    string
-	as    func(format string, args ...interface{})
+	t        testing.TB
+	mu       sync.RWMutex
+	cfg      config
+	ctx      context.Context
+	expired  *int32
+	parallel bool
+	group    *group
+}
+
+// config is the configuration of an Assert, modified by the setters.
+type config struct {
+	stack     bool
+	os        string
+	fatal     bool
+	context   int
+	reporters []Reporter
+	leaks     bool
+	grace     time.Duration
+	allowed   []*regexp.Regexp
+	timeout   time.Duration
+	before    []func(*Assert)
+	after     []func(*Assert)
+}
+
+// conf returns a copy of the configuration.
+func (a *Assert) conf() config {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.cfg
+}
+
+// set modifies the configuration.
+func (a *Assert) set(fn func(*config)) *Assert {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	fn(&a.cfg)
+	return a
 }
 
-func (a *Assert) clone(t *testing.T) *Assert {
-	fn := t.Errorf
-	f := os.Getenv("GO_ASSERT_FATAL")
-	if f == "true" || f == "t" || f == "1" {
-		fn = t.Fatalf
+func (a *Assert) clone(t testing.TB) *Assert {
+	cfg := a.conf()
+	cfg.fatal = envBool("GO_ASSERT_FATAL")
+	return &Assert{
+		t:        t,
+		cfg:      cfg,
+		ctx:      a.ctx,
+		expired:  a.expired,
+		parallel: a.parallel,
+		group:    &group{immediate: true},
 	}
-	return &Assert{t, a.stack, a.os, fn}
 }
 
 // New creates a new Assert object.
@@ -37,40 +82,65 @@ func (a *Assert) clone(t *testing.T) *Assert {
 // 	- GO_ASSERT_STACK: show the stacktrace if the test fails
 // 	- GO_ASSERT_FATAL: uses fatal errors
 // 	- GO_ASSERT_OS: test for a specific OS (the possible values are similar to runtime.GOOS)
-func New(t *testing.T) *Assert {
-	s := os.Getenv("GO_ASSERT_STACK")
-	stack := false
-	if s == "true" || s == "t" || s == "1" {
-		stack = true
-	}
-	fn := t.Errorf
-	f := os.Getenv("GO_ASSERT_FATAL")
-	if f == "true" || f == "t" || f == "1" {
-		fn = t.Fatalf
-	}
+// 	- GO_ASSERT_REPORT: writes a report file of the package (junit or json)
+// 	- GO_ASSERT_REPORT_DIR: directory of the report file
+// 	- GO_ASSERT_DIFF_CONTEXT: number of context lines of the diffs (3 by default)
+// 	- GO_ASSERT_UPDATE: updates the golden files of EqualFile
+// 	- GO_ASSERT_UPDATE_BASELINE: updates the baseline files of Baseline
+//
+// The failures are sent to the reporters, by default in the test log (see
+// LogReporter).
+func New(t testing.TB, reporters ...Reporter) *Assert {
 	o := os.Getenv("GO_ASSERT_OS")
 	if o != "" {
 
 	}
-	return &Assert{t, stack, "all", fn}
+	context := defaultDiffContext
+	if c := os.Getenv("GO_ASSERT_DIFF_CONTEXT"); c != "" {
+		n, err := strconv.Atoi(c)
+		if err != nil {
+			panic(fmt.Sprintf("GO_ASSERT_DIFF_CONTEXT must be an integer: %s", c))
+		}
+		context = n
+	}
+	if updateMode() {
+		goldenSummary(t)
+	}
+	obsoleteSnapshots(t)
+	reporters = defaultReporters(reporters)
+	if fileReports.enabled() {
+		fileReports.root(t)
+		reporters = append(reporters[:len(reporters):len(reporters)], fileReports)
+	}
+	return &Assert{t: t, group: &group{immediate: true}, cfg: config{
+		stack:     envBool("GO_ASSERT_STACK"),
+		os:        "all",
+		fatal:     envBool("GO_ASSERT_FATAL"),
+		context:   context,
+		reporters: reporters,
+		grace:     defaultLeakGrace,
+	}}
 }
 
 // NewCustom is similar to New but not uses the environment variables.
 //
 // If stack is true then the stacktrace is showed; if fatal is true then uses
 // the fatal errors.
-func NewCustom(t *testing.T, fatal, stack bool) *Assert {
-	fn := t.Errorf
-	if fatal {
-		fn = t.Fatalf
-	}
-	return &Assert{t, stack, "all", fn}
+func NewCustom(t testing.TB, fatal, stack bool, reporters ...Reporter) *Assert {
+	return &Assert{t: t, group: &group{immediate: true}, cfg: config{
+		stack:     stack,
+		os:        "all",
+		fatal:     fatal,
+		context:   defaultDiffContext,
+		reporters: defaultReporters(reporters),
+		grace:     defaultLeakGrace,
+	}}
 }
 
 // assert wraps the other methods. It should not used directly.
 func (a *Assert) assert(fn func()) *Assert {
-	if a.os != "all" {
-		if runtime.GOOS != a.os {
+	if o := a.conf().os; o != "all" {
+		if runtime.GOOS != o {
 			return a
 		}
 	}
@@ -79,39 +149,121 @@ func (a *Assert) assert(fn func()) *Assert {
 }
 
 // errorMessage is an helper to show homogeneous messages.
-func (a *Assert) errorMessage(f string, args ...interface{}) func(...interface{}) *Assert {
+//
+// name is the name of the assertion, exp and got are the values sent to the
+// reporters (nil if not relevant).
+func (a *Assert) errorMessage(name string, exp, got interface{}, f string, args ...interface{}) func(...interface{}) *Assert {
 	return func(msg ...interface{}) *Assert {
-		if a.stack {
-			debug.PrintStack()
+		failure := Failure{
+			Assertion: name,
+			Exp:       exp,
+			Got:       got,
+			Text:      errorMessage(fmt.Sprintf(f, args...), msg...),
+			Message:   userMessage(msg...),
+			Frame:     callerFrame(),
 		}
-		m := errorMessage(fmt.Sprintf(f, args...), msg...)
-		a.as(m)
+		if a.conf().stack {
+			failure.Stack = debug.Stack()
+		}
+		a.report(failure)
 		return a
 	}
 }
 
+// report sends the failure to the reporters and marks the test as failed.
+//
+// The failures of a subtest that has timed out are ignored: its test is
+// already completed.
+func (a *Assert) report(f Failure) {
+	if a.expired != nil && atomic.LoadInt32(a.expired) == 1 {
+		return
+	}
+	cfg := a.conf()
+	for _, r := range cfg.reporters {
+		r.Report(a.t, f)
+	}
+	if cfg.fatal {
+		a.t.FailNow()
+	}
+	a.t.Fail()
+}
+
 // SetStack sets to true if the stacktrace must be showed after an error.
 func (a *Assert) SetStack(v bool) *Assert {
-	a.stack = v
-	return a
+	return a.set(func(c *config) { c.stack = v })
 }
 
 // SetFatal sets to true if the assert must use the Fatal method.
 func (a *Assert) SetFatal(v bool) *Assert {
-	fn := a.t.Errorf
-	if v {
-		fn = a.t.Fatalf
+	return a.set(func(c *config) { c.fatal = v })
+}
+
+// SetDiffContext sets the number of context lines showed around the
+// differences of the multi-line strings.
+func (a *Assert) SetDiffContext(n int) *Assert {
+	return a.set(func(c *config) { c.context = n })
+}
+
+// SetLeakCheck sets to true if the subtests must check the goroutine leaks:
+// the goroutines created by a subtest and still alive after a grace period
+// fail the subtest with their stacktraces (see SetLeakGrace).
+//
+// A goroutine belongs to the subtest if it is created by the goroutine of the
+// subtest or by one of its goroutines, so the goroutines of the tests executed
+// in parallel are not reported. Before Go 1.21 the stacktraces do not contain
+// the creator of the goroutines: all the new goroutines are checked, avoid the
+// parallel tests with the leak check.
+func (a *Assert) SetLeakCheck(v bool) *Assert {
+	return a.set(func(c *config) { c.leaks = v })
+}
+
+// SetLeakGrace sets the maximum time waited for the end of the goroutines
+// created by a subtest before reporting them as leaks (1 second by default).
+func (a *Assert) SetLeakGrace(d time.Duration) *Assert {
+	return a.set(func(c *config) { c.grace = d })
+}
+
+// AllowGoroutines adds regex patterns of the known background goroutines
+// ignored by the leak check. A goroutine is ignored if its stacktrace matches
+// a pattern.
+//
+// Example:
+// 	a.SetLeakCheck(true).AllowGoroutines(`mypkg\.\(\*Pool\)\.worker`)
+func (a *Assert) AllowGoroutines(patterns ...string) *Assert {
+	res := make([]*regexp.Regexp, len(patterns))
+	for i, p := range patterns {
+		res[i] = regexp.MustCompile(p)
 	}
-	a.as = fn
-	return a
+	return a.set(func(c *config) {
+		c.allowed = append(c.allowed[:len(c.allowed):len(c.allowed)], res...)
+	})
+}
+
+// SetTimeout sets the maximum duration of the subtests (It, ItTmp, ItEnv,
+// Capture and Crash); 0 disables the timeout. See ItTimeout.
+func (a *Assert) SetTimeout(d time.Duration) *Assert {
+	return a.set(func(c *config) { c.timeout = d })
+}
+
+// Context returns the context of the subtest, canceled at the timeout of the
+// subtest (see SetTimeout and ItTimeout).
+func (a *Assert) Context() context.Context {
+	if a.ctx == nil {
+		return context.Background()
+	}
+	return a.ctx
+}
+
+// SetReporters replaces the reporters of the failures.
+func (a *Assert) SetReporters(reporters ...Reporter) *Assert {
+	return a.set(func(c *config) { c.reporters = defaultReporters(reporters) })
 }
 
 // SetOS specifies if the test is operating system dependant.
 //
 // The possible values are similar to the values of runtime.GOOS.
 func (a *Assert) SetOS(v string) *Assert {
-	a.os = v
-	return a
+	return a.set(func(c *config) { c.os = v })
 }
 
 // Skip the test.
@@ -121,13 +273,143 @@ func (a *Assert) Skip(args ...interface{}) *Assert {
 }
 
 // It defines a new subtest.
+//
+// With a testing.B, the subtest is a sub-benchmark executed only once: use
+// ItBench to measure a function.
 func (a *Assert) It(msg string, fn func(*Assert)) *Assert {
-	a.t.Run(msg, func(t *testing.T) {
-		fn(a.clone(t))
+	return a.subtest(msg, false, fn)
+}
+
+// ItParallel is similar to It but the subtest is executed in parallel with the
+// other parallel subtests (see testing.T.Parallel).
+func (a *Assert) ItParallel(msg string, fn func(*Assert)) *Assert {
+	return a.subtest(msg, true, fn)
+}
+
+// subtest executes fn in a new subtest with a clone of the Assert. The clone
+// is created before the subtest: the next modifications of the Assert do not
+// change the subtest.
+func (a *Assert) subtest(msg string, parallel bool, fn func(*Assert)) *Assert {
+	return a.define(msg, parallel, false, "", fn)
+}
+
+// define defines a subtest: it is executed immediately or at the end of the
+// Describe group of the Assert (see schedule). The subtest is skipped with the
+// reason skip if it is not empty.
+func (a *Assert) define(msg string, parallel, focus bool, skip string, fn func(*Assert)) *Assert {
+	c := a.clone(a.t)
+	c.parallel = a.parallel || parallel
+	fn = c.hooks(fn)
+	return a.schedule(groupTest{focus: focus, skip: skip, start: func(parent testing.TB, skip string) {
+		c.start(parent, msg, parallel, skip, fn)
+	}})
+}
+
+// start executes fn in a new subtest of the parent test.
+func (a *Assert) start(parent testing.TB, msg string, parallel bool, skip string, fn func(*Assert)) {
+	run(parent, msg, func(t testing.TB) {
+		a.t = t
+		if skip != "" {
+			a.Skip(skip)
+		}
+		if p, ok := t.(interface{ Parallel() }); ok && parallel {
+			p.Parallel()
+		}
+		cfg := a.conf()
+		if cfg.leaks {
+			defer a.checkLeaks(takeSnapshot())
+		}
+		if cfg.timeout > 0 {
+			a.runTimeout(fn)
+			return
+		}
+		fn(a)
+	})
+}
+
+// ItTimeout is similar to It but the subtest fails if it is not completed
+// after the duration d. The context of the Assert is canceled at the timeout.
+//
+// Example:
+// 	a.ItTimeout("sub test", time.Second, func(a *T.Assert) {
+// 		res, err := client.Get(a.Context(), "key")
+// 		// ...
+// 	})
+func (a *Assert) ItTimeout(msg string, d time.Duration, fn func(*Assert)) *Assert {
+	a.dup().SetTimeout(d).subtest(msg, false, fn)
+	return a
+}
+
+// dup returns a copy of the Assert.
+func (a *Assert) dup() *Assert {
+	b := a.clone(a.t)
+	b.cfg.fatal = a.conf().fatal
+	b.group = a.group
+	return b
+}
+
+// ItBench defines a new sub-benchmark. The Assert must wrap a testing.B.
+//
+// Example:
+// 	T.New(b).ItBench("Sub benchmark", func(a *T.Assert, b *testing.B) {
+// 		for i := 0; i < b.N; i++ {
+// 			a.Equal(4, 2+2)
+// 		}
+// 	})
+func (a *Assert) ItBench(msg string, fn func(*Assert, *testing.B)) *Assert {
+	b, ok := a.t.(*testing.B)
+	if !ok {
+		a.t.Fatalf("ItBench requires a *testing.B, got: %T", a.t)
+		return a
+	}
+	b.Run(msg, func(b *testing.B) {
+		fn(a.clone(b), b)
 	})
 	return a
 }
 
+// Fuzz runs the fuzz target fn. The Assert must wrap a testing.F.
+//
+// fn is similar to the function of testing.F.Fuzz but the first argument is an
+// *Assert instead of a *testing.T. The failures are reported to the fuzzer
+// even in fatal mode (SetFatal or GO_ASSERT_FATAL) so the failing inputs are
+// written in the corpus.
+//
+// The seeds are files or folders walked recursively like the sources of Cp,
+// the content of each file is added to the seed corpus: fn must have a single
+// []byte or string argument after the Assert.
+//
+// Example:
+// 	func FuzzXXX(f *testing.F) {
+// 		f.Add("seed", 3)
+// 		T.New(f).Fuzz(func(a *T.Assert, s string, n int) {
+// 			// ...
+// 		})
+// 	}
+//
+// 	func FuzzParse(f *testing.F) {
+// 		T.New(f).Fuzz(func(a *T.Assert, data []byte) {
+// 			_, err := Parse(data)
+// 			a.Nil(err)
+// 		}, "testdata/valid", "testdata/invalid.json")
+// 	}
+func (a *Assert) Fuzz(fn interface{}, seeds ...string) {
+	f, ok := a.t.(*testing.F)
+	if !ok {
+		a.t.Fatalf("Fuzz requires a *testing.F, got: %T", a.t)
+		return
+	}
+	if len(seeds) > 0 {
+		typ := seedType(fn)
+		for _, s := range seeds {
+			for _, data := range readSeeds(s) {
+				f.Add(reflect.ValueOf(data).Convert(typ).Interface())
+			}
+		}
+	}
+	f.Fuzz(fuzzAdapter(a, fn))
+}
+
 // Equal assertion.
 //
 // The assertion function can add an optional custom error message:
@@ -137,19 +419,31 @@ func (a *Assert) It(msg string, fn func(*Assert)) *Assert {
 //
 // The assertion function can be chained:
 // 	a.Nil(...).Equal(...).True(...)
+//
+// If the values are strings with several lines, the message shows the lines
+// that differ (see SetDiffContext).
 func (a *Assert) Equal(exp, got interface{}, msg ...interface{}) *Assert {
 	return a.assert(func() {
 		if exp != got {
-			a.errorMessage("Exp: %+v\nGot: %+v\n", exp, got)(msg...)
+			a.errorMessage("Equal", exp, got, "%s", a.equalMessage(exp, got))(msg...)
 		}
 	})
 }
 
+// equalMessage returns the error message of two different values: a line diff
+// for the multi-line strings, else the values.
+func (a *Assert) equalMessage(exp, got interface{}) string {
+	if isMultiline(exp) || isMultiline(got) {
+		return lineDiff(fmt.Sprint(exp), fmt.Sprint(got), a.conf().context)
+	}
+	return fmt.Sprintf("Exp: %+v\nGot: %+v\n", exp, got)
+}
+
 // EqualFAbs compares 2 floats numbers with an absolute tolerance.
 func (a *Assert) EqualFAbs(exp, got, epsilon float64, msg ...interface{}) *Assert {
 	return a.assert(func() {
 		if !compareAbs(exp, got, epsilon) {
-			a.errorMessage("Exp: %f\nGot: %f with an absolute tolerance: %f\n", exp, got, epsilon)(msg...)
+			a.errorMessage("EqualFAbs", exp, got, "Exp: %f\nGot: %f with an absolute tolerance: %f\n", exp, got, epsilon)(msg...)
 		}
 	})
 }
@@ -158,7 +452,7 @@ func (a *Assert) EqualFAbs(exp, got, epsilon float64, msg ...interface{}) *Asser
 func (a *Assert) EqualFRel(exp, got, epsilon float64, msg ...interface{}) *Assert {
 	return a.assert(func() {
 		if !compareRel(exp, got, epsilon) {
-			a.errorMessage("Exp: %f\nGot: %f with an relative tolerance: %f\n", exp, got, epsilon)(msg...)
+			a.errorMessage("EqualFRel", exp, got, "Exp: %f\nGot: %f with an relative tolerance: %f\n", exp, got, epsilon)(msg...)
 		}
 	})
 }
@@ -167,7 +461,7 @@ func (a *Assert) EqualFRel(exp, got, epsilon float64, msg ...interface{}) *Asser
 func (a *Assert) True(v bool, msg ...interface{}) *Assert {
 	return a.assert(func() {
 		if !v {
-			a.errorMessage("Not true")(msg...)
+			a.errorMessage("True", true, v, "Not true")(msg...)
 		}
 	})
 }
@@ -176,7 +470,7 @@ func (a *Assert) True(v bool, msg ...interface{}) *Assert {
 func (a *Assert) False(v bool, msg ...interface{}) *Assert {
 	return a.assert(func() {
 		if v {
-			a.errorMessage("Not false")(msg...)
+			a.errorMessage("False", false, v, "Not false")(msg...)
 		}
 	})
 }
@@ -185,16 +479,21 @@ func (a *Assert) False(v bool, msg ...interface{}) *Assert {
 func (a *Assert) NotEqual(exp, got interface{}, msg ...interface{}) *Assert {
 	return a.assert(func() {
 		if exp == got {
-			a.errorMessage("Equal: %+v\n", exp)(msg...)
+			a.errorMessage("NotEqual", exp, got, "Equal: %+v\n", exp)(msg...)
 		}
 	})
 }
 
 // EqualDeep is similar to Equal but uses reflect.DeepEqual to test the equality.
+//
+// If the assertion fails then a message shows the path of each difference:
+// 	Error:
+// 	  Differences:
+// 	  .Users[3].Address.Zip: exp "1000" got "1001"
 func (a *Assert) EqualDeep(exp, got interface{}, msg ...interface{}) *Assert {
 	return a.assert(func() {
 		if !reflect.DeepEqual(exp, got) {
-			a.errorMessage("Exp: %#v\nGot: %#v\n", exp, got)(msg...)
+			a.errorMessage("EqualDeep", exp, got, "%s", deepMessage(exp, got))(msg...)
 		}
 	})
 }
@@ -202,8 +501,8 @@ func (a *Assert) EqualDeep(exp, got interface{}, msg ...interface{}) *Assert {
 // NotEqualDeep is the inverse of EqualDeep.
 func (a *Assert) NotEqualDeep(exp, got interface{}, msg ...interface{}) *Assert {
 	return a.assert(func() {
-		if reflect.DeepEqual(exp, got) {
-			a.errorMessage("Equal: %#v\n", exp)(msg...)
+		if len(diffValues(exp, got)) == 0 {
+			a.errorMessage("NotEqualDeep", exp, got, "Equal: %#v\n", exp)(msg...)
 		}
 	})
 }
@@ -212,18 +511,14 @@ func (a *Assert) NotEqualDeep(exp, got interface{}, msg ...interface{}) *Assert
 func (a *Assert) EqualSlice(exp, got []interface{}, msg ...interface{}) *Assert {
 	return a.assert(func() {
 		if len(exp) != len(got) {
-			a.errorMessage("Expected size: %d, got size: %d", len(exp), len(got))(msg...)
+			a.errorMessage("EqualSlice", exp, got, "Expected size: %d, got size: %d", len(exp), len(got))(msg...)
 			return
 		}
-		dash := 4
-		for i := range exp {
-			s := fmt.Sprintf("%+v", exp[i])
-			dash += len(s)
-			if exp[i] != got[i] {
-				d := strings.Repeat("━", dash)
-				a.errorMessage("Exp: %+v\nGot: %+v\n ┗%s┛\n", exp, got, d)(msg...)
-				return
-			}
+		eq := func(x, y interface{}) bool { return x == y }
+		if dash := sliceMismatch(exp, got, eq); dash > 0 {
+			d := strings.Repeat("━", dash)
+			a.errorMessage("EqualSlice", exp, got, "Exp: %+v\nGot: %+v\n ┗%s┛\n%s", exp, got, d,
+				formatDiffs(diffValues(exp, got)))(msg...)
 		}
 	})
 }
@@ -238,18 +533,13 @@ func (a *Assert) EqualSlice(exp, got []interface{}, msg ...interface{}) *Assert
 func (a *Assert) EqualStringSlice(exp, got []string, msg ...interface{}) *Assert {
 	return a.assert(func() {
 		if len(exp) != len(got) {
-			a.errorMessage("Expected size: %d, got size: %d", len(exp), len(got))(msg...)
+			a.errorMessage("EqualStringSlice", exp, got, "Expected size: %d, got size: %d", len(exp), len(got))(msg...)
 			return
 		}
-		dash := 4
-		for i := range exp {
-			s := fmt.Sprintf("%+v", exp[i])
-			dash += len(s)
-			if exp[i] != got[i] {
-				d := strings.Repeat("━", dash)
-				a.errorMessage("Exp: %s\nGot: %s\n ┗%s┛\n", exp, got, d)(msg...)
-				return
-			}
+		eq := func(x, y string) bool { return x == y }
+		if dash := sliceMismatch(exp, got, eq); dash > 0 {
+			d := strings.Repeat("━", dash)
+			a.errorMessage("EqualStringSlice", exp, got, "Exp: %s\nGot: %s\n ┗%s┛\n", exp, got, d)(msg...)
 		}
 	})
 }
@@ -262,11 +552,11 @@ func (a *Assert) Nil(v interface{}, msg ...interface{}) *Assert {
 		}
 		defer func() {
 			if err := recover(); err != nil {
-				a.errorMessage("%#v is not a nullable value", v)()
+				a.errorMessage("Nil", nil, v, "%#v is not a nullable value", v)()
 			}
 		}()
 		if !reflect.ValueOf(v).IsNil() {
-			a.errorMessage("Nil expected: %#v", v)(msg...)
+			a.errorMessage("Nil", nil, v, "Nil expected: %#v", v)(msg...)
 		}
 	})
 }
@@ -277,11 +567,11 @@ func (a *Assert) NotNil(v interface{}, msg ...interface{}) *Assert {
 		if v != nil {
 			defer func() {
 				if err := recover(); err != nil {
-					a.errorMessage("%#v is not a nullable value", v)()
+					a.errorMessage("NotNil", nil, v, "%#v is not a nullable value", v)()
 				}
 			}()
 			if reflect.ValueOf(v).IsNil() {
-				a.errorMessage("Not nil expected")(msg...)
+				a.errorMessage("NotNil", nil, v, "Not nil expected")(msg...)
 			}
 		}
 	})
@@ -297,11 +587,11 @@ func (a *Assert) Error(err error, errFormat string, errA ...interface{}) *Assert
 	return a.assert(func() {
 		exp := fmt.Sprintf(errFormat, errA...)
 		if err == nil {
-			a.errorMessage("Expected error with message: %s", exp)()
+			a.errorMessage("Error", exp, err, "Expected error with message: %s", exp)()
 			return
 		}
 		if err.Error() != exp {
-			a.errorMessage("Error message mismatch\nExp: %s\nGot: %s\n", exp, err.Error())()
+			a.errorMessage("Error", exp, err, "Error message mismatch\nExp: %s\nGot: %s\n", exp, err.Error())()
 		}
 	})
 }
@@ -317,14 +607,22 @@ func (a *Assert) Match(pattern, s string, msg ...interface{}) *Assert {
 			panic(err)
 		}
 		if !m {
-			a.errorMessage("Regex (%s) mismatch: %s", pattern, s)(msg...)
+			a.errorMessage("Match", pattern, s, "Regex (%s) mismatch: %s", pattern, s)(msg...)
 		}
 	})
 }
 
 // EqualFile tests the equality between the content of file and the got string.
+//
+// In update mode (environment variable GO_ASSERT_UPDATE=1), the got string is
+// written in the file instead, and the parent directories are created:
+// 	GO_ASSERT_UPDATE=1 go test ./...
 func (a *Assert) EqualFile(got string, filename string, msg ...interface{}) *Assert {
 	return a.assert(func() {
+		if updateMode() {
+			updateGolden(a.t, got, filename)
+			return
+		}
 		fi, err := ioutil.ReadFile(filename)
 		if err != nil {
 			panic(err)
@@ -362,7 +660,7 @@ func (a *Assert) EqualTemplate(got string, filename string, data interface{}, ms
 func (a *Assert) IsFile(pth string, msg ...interface{}) *Assert {
 	return a.assert(func() {
 		if !isFile(pth) {
-			a.errorMessage("Not a file: %s", pth)(msg...)
+			a.errorMessage("IsFile", nil, pth, "Not a file: %s", pth)(msg...)
 		}
 	})
 }
@@ -371,7 +669,7 @@ func (a *Assert) IsFile(pth string, msg ...interface{}) *Assert {
 func (a *Assert) IsDir(pth string, msg ...interface{}) *Assert {
 	return a.assert(func() {
 		if !isDir(pth) {
-			a.errorMessage("Not a directory: %s", pth)(msg...)
+			a.errorMessage("IsDir", nil, pth, "Not a directory: %s", pth)(msg...)
 		}
 	})
 }
@@ -380,7 +678,7 @@ func (a *Assert) IsDir(pth string, msg ...interface{}) *Assert {
 func (a *Assert) NotExists(pth string, msg ...interface{}) *Assert {
 	return a.assert(func() {
 		if exists(pth) {
-			a.errorMessage("Expected not exists: %s", pth)(msg...)
+			a.errorMessage("NotExists", nil, pth, "Expected not exists: %s", pth)(msg...)
 		}
 	})
 }
@@ -396,12 +694,21 @@ func (a *Assert) NotExists(pth string, msg ...interface{}) *Assert {
 // 		// dir is the temporary directory
 // 	})
 func (a *Assert) ItTmp(msg string, fn func(*Assert, string)) *Assert {
-	a.t.Run(msg, func(t *testing.T) {
+	return a.itTmp(msg, false, fn)
+}
+
+// ItTmpParallel is similar to ItTmp but the subtest is executed in parallel
+// (see ItParallel).
+func (a *Assert) ItTmpParallel(msg string, fn func(*Assert, string)) *Assert {
+	return a.itTmp(msg, true, fn)
+}
+
+func (a *Assert) itTmp(msg string, parallel bool, fn func(*Assert, string)) *Assert {
+	return a.subtest(msg, parallel, func(a *Assert) {
 		tmpDir(func(dir string) {
-			fn(a.clone(t), dir)
+			fn(a, dir)
 		})
 	})
-	return a
 }
 
 // Copy is a file or a directory copied in the temporary directory.
@@ -421,18 +728,27 @@ type Copy struct {
 // 	// ...
 // 	})
 func (a *Assert) ItEnv(msg string, copies ...Copy) func(func(*Assert, string)) *Assert {
+	return a.itEnv(msg, false, copies)
+}
+
+// ItEnvParallel is similar to ItEnv but the subtest is executed in parallel
+// (see ItParallel).
+func (a *Assert) ItEnvParallel(msg string, copies ...Copy) func(func(*Assert, string)) *Assert {
+	return a.itEnv(msg, true, copies)
+}
+
+func (a *Assert) itEnv(msg string, parallel bool, copies []Copy) func(func(*Assert, string)) *Assert {
 	return func(fn func(*Assert, string)) *Assert {
-		a.t.Run(msg, func(t *testing.T) {
+		return a.subtest(msg, parallel, func(a *Assert) {
 			tmpDir(func(dir string) {
 				for _, c := range copies {
 					if err := Cp(c.Source, filepath.Join(dir, c.Dest)); err != nil {
 						panic(err)
 					}
 				}
-				fn(a.clone(t), dir)
+				fn(a, dir)
 			})
 		})
-		return a
 	}
 }
 
@@ -445,20 +761,38 @@ func (a *Assert) ItEnv(msg string, copies ...Copy) func(func(*Assert, string)) *
 // 	}, func(a *T.Assert, stdout, stderr string) {
 // 		a.Equal("Hello", stdout).Equal("World", stderr)
 // 	})
+//
+// The standard output and error are global: Capture fails in the parallel
+// subtests and if another Capture is running.
 func (a *Assert) Capture(msg string, act func(), fn func(*Assert, string, string)) *Assert {
-	a.t.Run(msg, func(t *testing.T) {
-		stdOut, stdErr := captureOutput(act)
-		fn(a.clone(t), stdOut, stdErr)
+	return a.subtest(msg, false, func(a *Assert) {
+		if !a.lockCapture() {
+			return
+		}
+		stdOut, stdErr := captureOutput(a.Context(), act)
+		fn(a, stdOut, stdErr)
 	})
-	return a
+}
+
+// lockCapture acquires the capture of the standard output and reports a
+// fatal error if it is not allowed.
+func (a *Assert) lockCapture() bool {
+	switch {
+	case a.parallel:
+		a.SetFatal(true).errorMessage("Capture", nil, nil, "Capture cannot be used in a parallel subtest")()
+	case !captureMu.TryLock():
+		a.SetFatal(true).errorMessage("Capture", nil, nil, "Capture cannot run concurrently with another Capture")()
+	default:
+		return true
+	}
+	return false
 }
 
 // Crash is similar to Capture but the function should exit the program too.
 // The assertion captures the return code too.
 func (a *Assert) Crash(msg string, act func(), fn func(*Assert, int, string, string)) *Assert {
-	a.t.Run(msg, func(t *testing.T) {
-		rc, stdOut, stdErr := crashTest(t, act)
-		fn(a.clone(t), rc, stdOut, stdErr)
+	return a.subtest(msg, false, func(a *Assert) {
+		rc, stdOut, stdErr := crashTest(a.t, act)
+		fn(a, rc, stdOut, stdErr)
 	})
-	return a
 }
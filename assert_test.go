@@ -5,10 +5,12 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
-func isAssert(t *testing.T, a interface{}) {
+func isAssert(t testing.TB, a interface{}) {
 	to := reflect.TypeOf(a)
 	if to.String() != "*assert.Assert" {
 		t.Errorf("is not an Assert: %#v => %s", a, to.String())
@@ -56,6 +58,41 @@ func TestAssertIt(t *testing.T) {
 	isAssert(t, a)
 }
 
+func TestAssertItParallel(t *testing.T) {
+	var n int32
+	a := New(t)
+	isAssert(t, a.It("group", func(a *Assert) {
+		for i := 0; i < 4; i++ {
+			a.ItParallel(fmt.Sprintf("sub test %d", i), func(a *Assert) {
+				if !a.parallel {
+					t.Errorf("subtest not parallel")
+				}
+				a.SetStack(true).Equal(1, 1)
+				atomic.AddInt32(&n, 1)
+			})
+		}
+	}))
+	if n != 4 {
+		t.Errorf("got: %d, exp: 4", n)
+	}
+	if a.parallel {
+		t.Errorf("parent is parallel")
+	}
+}
+
+func TestAssertConcurrent(t *testing.T) {
+	a := New(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			a.SetStack(i%2 == 0).SetDiffContext(i).Equal(i, i)
+		}(i)
+	}
+	wg.Wait()
+}
+
 func TestAssertEqual(t *testing.T) {
 	isAssert(t, New(t).Equal("a", "a", "format str"))
 }
@@ -179,6 +216,22 @@ func TestAssertCapture(t *testing.T) {
 	}))
 }
 
+func TestAssertCaptureParallel(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	a := New(tb)
+	a.parallel = true
+	if a.lockCapture() || !tb.failed {
+		t.Errorf("capture allowed in parallel subtest")
+	}
+	tb = &fakeTB{TB: t}
+	a = New(tb)
+	captureMu.Lock()
+	if a.lockCapture() || !tb.failed {
+		t.Errorf("concurrent capture allowed")
+	}
+	captureMu.Unlock()
+}
+
 func TestAssertCrash(t *testing.T) {
 	isAssert(t, New(t).Crash("crash", func() {
 		fmt.Println("Hello")
@@ -198,3 +251,37 @@ func TestAssertCrash(t *testing.T) {
 		}
 	}))
 }
+
+func TestAssertBenchmark(t *testing.T) {
+	n := 0
+	testing.Benchmark(func(b *testing.B) {
+		New(b).It("sub benchmark", func(a *Assert) {
+			isAssert(t, a)
+			n++
+		})
+	})
+	if n != 1 {
+		t.Errorf("got: %d, exp: 1", n)
+	}
+}
+
+func TestAssertItBench(t *testing.T) {
+	n := 0
+	testing.Benchmark(func(b *testing.B) {
+		New(b).ItBench("sub benchmark", func(a *Assert, b *testing.B) {
+			isAssert(t, a)
+			n += b.N
+		})
+	})
+	if n == 0 {
+		t.Errorf("sub benchmark not executed")
+	}
+}
+
+func FuzzAssert(f *testing.F) {
+	f.Add("a", 1)
+	f.Add("bb", 2)
+	New(f).Fuzz(func(a *Assert, s string, n int) {
+		a.Equal(s, s).Equal(n, n)
+	})
+}
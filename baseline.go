@@ -0,0 +1,150 @@
+package assert
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// baseline contains the measures of a mini benchmark.
+type baseline struct {
+	nsPerOp     float64
+	allocsPerOp float64
+}
+
+// Baseline runs fn as a benchmark (see testing.Benchmark) and compares the
+// ns/op and the allocs/op with the baseline file. The assertion fails if a measure is
+// greater than the baseline by more than the relative tolerance:
+//
+//	a.Baseline("testdata/parse.bench", 0.2, func() {
+//		Parse(input)
+//	})
+//
+// With the environment variable GO_ASSERT_UPDATE_BASELINE=1, the measures are
+// written in the baseline file instead. The update mode of the golden files
+// (GO_ASSERT_UPDATE) does not change the baselines:
+//
+//	GO_ASSERT_UPDATE_BASELINE=1 go test -run TestParse ./...
+func (a *Assert) Baseline(filename string, tolerance float64, fn func(), msg ...interface{}) *Assert {
+	return a.assert(func() {
+		got := baseline{
+			nsPerOp:     math.Round(nsPerOp(fn)*10) / 10,
+			allocsPerOp: testing.AllocsPerRun(perfRuns, fn),
+		}
+		if baselineUpdateMode() {
+			writeBaseline(a.t, got.String(), filename)
+			return
+		}
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			panic(err)
+		}
+		exp, err := parseBaseline(string(content))
+		if err != nil {
+			panic(fmt.Sprintf("invalid baseline file %s: %s", filename, err))
+		}
+		var b strings.Builder
+		if exceedsRel(exp.nsPerOp, got.nsPerOp, tolerance) {
+			fmt.Fprintf(&b, "ns/op:     %s -> %s (%+.1f%%)\n", formatMeasure(exp.nsPerOp), formatMeasure(got.nsPerOp), change(exp.nsPerOp, got.nsPerOp))
+		}
+		if exceedsRel(exp.allocsPerOp, got.allocsPerOp, tolerance) {
+			fmt.Fprintf(&b, "allocs/op: %s -> %s (%+.1f%%)\n", formatMeasure(exp.allocsPerOp), formatMeasure(got.allocsPerOp), change(exp.allocsPerOp, got.allocsPerOp))
+		}
+		if b.Len() > 0 {
+			a.errorMessage("Baseline", exp.String(), got.String(), "Regression of %s with a relative tolerance: %.0f%%\n%s",
+				filename, tolerance*100, b.String())(msg...)
+		}
+	})
+}
+
+// baselineUpdateMode returns true if the baseline files must be updated
+// instead of compared. The update mode is enabled with the environment
+// variable GO_ASSERT_UPDATE_BASELINE.
+func baselineUpdateMode() bool {
+	return envBool("GO_ASSERT_UPDATE_BASELINE")
+}
+
+// nsPerOp returns the duration of a call of fn in nanoseconds, measured by
+// testing.Benchmark on batches of calls (see the flag -test.benchtime).
+func nsPerOp(fn func()) float64 {
+	r := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fn()
+		}
+	})
+	if r.N == 0 {
+		return 0
+	}
+	return float64(r.T.Nanoseconds()) / float64(r.N)
+}
+
+// writeBaseline writes the measures in the baseline file. The parent
+// directories are created.
+func writeBaseline(t testing.TB, content, filename string) {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+		panic(err)
+	}
+	t.Logf("Baseline updated: %s", filename)
+}
+
+// String returns the content of the baseline file.
+func (b baseline) String() string {
+	return fmt.Sprintf("ns/op: %s\nallocs/op: %s\n", formatMeasure(b.nsPerOp), formatMeasure(b.allocsPerOp))
+}
+
+// parseBaseline parses the content of a baseline file.
+func parseBaseline(content string) (baseline, error) {
+	var b baseline
+	found := map[string]bool{}
+	s := bufio.NewScanner(strings.NewReader(content))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			return b, fmt.Errorf("invalid line: %q", line)
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil {
+			return b, fmt.Errorf("invalid value: %q", line)
+		}
+		key := strings.TrimSpace(parts[0])
+		switch key {
+		case "ns/op":
+			b.nsPerOp = v
+		case "allocs/op":
+			b.allocsPerOp = v
+		default:
+			return b, fmt.Errorf("unknown measure: %q", key)
+		}
+		found[key] = true
+	}
+	if !found["ns/op"] || !found["allocs/op"] {
+		return b, fmt.Errorf("ns/op and allocs/op are required")
+	}
+	return b, nil
+}
+
+func formatMeasure(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// change returns the relative change between the baseline and the measure
+// in percent.
+func change(base, got float64) float64 {
+	if base == 0 {
+		return 100
+	}
+	return (got - base) / base * 100
+}
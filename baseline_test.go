@@ -0,0 +1,62 @@
+package assert
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBaseline(t *testing.T) {
+	benchtime := flag.Lookup("test.benchtime").Value.String()
+	flag.Set("test.benchtime", "10ms")
+	defer flag.Set("test.benchtime", benchtime)
+	file := filepath.Join(t.TempDir(), "testdata", "bench.txt")
+	t.Setenv("GO_ASSERT_UPDATE_BASELINE", "1")
+	isAssert(t, New(t).Baseline(file, 0.2, func() {}))
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(content), "ns/op: ") || !strings.HasSuffix(string(content), "allocs/op: 0\n") {
+		t.Errorf("invalid baseline: %q", content)
+	}
+	goldens.Lock()
+	files := goldens.updated[t.Name()]
+	goldens.Unlock()
+	if len(files) != 0 {
+		t.Errorf("baseline listed in the golden files: %v", files)
+	}
+
+	t.Setenv("GO_ASSERT_UPDATE_BASELINE", "")
+	t.Setenv("GO_ASSERT_UPDATE", "1")
+	if err := ioutil.WriteFile(file, []byte("ns/op: 1000000\nallocs/op: 0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	isAssert(t, New(t).Baseline(file, 0.2, func() {}))
+	if content, err := ioutil.ReadFile(file); err != nil || string(content) != "ns/op: 1000000\nallocs/op: 0\n" {
+		t.Errorf("baseline updated with the golden files: %q, %v", content, err)
+	}
+
+	b, got := failures(t)
+	b.Baseline(file, 0.2, func() { perfSink = make([]byte, 64) })
+	if len(*got) != 1 || !strings.Contains((*got)[0].Text, "allocs/op: 0 -> 1 (+100.0%)") {
+		t.Errorf("regression not detected: %v", *got)
+	}
+}
+
+func TestParseBaseline(t *testing.T) {
+	b, err := parseBaseline("ns/op: 12.5\n\nallocs/op: 3\n")
+	if err != nil || b.nsPerOp != 12.5 || b.allocsPerOp != 3 {
+		t.Errorf("invalid baseline: %+v, %v", b, err)
+	}
+	if b.String() != "ns/op: 12.5\nallocs/op: 3\n" {
+		t.Errorf("invalid content: %q", b.String())
+	}
+	for _, s := range []string{"ns/op: 1", "ns/op 1\nallocs/op: 2", "ns/op: a\nallocs/op: 2", "B/op: 1"} {
+		if _, err := parseBaseline(s); err == nil {
+			t.Errorf("invalid baseline parsed: %q", s)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// Contains asserts that the container contains the element:
+//   - string: the element is a substring
+//   - slice or array: the element is equal (see EqualDeep) to an item
+//   - map: the element is a key
+//
+// The channels are not supported: checking their elements would consume
+// them. Only Len, Empty and NotEmpty accept the channels.
+func (a *Assert) Contains(container, elem interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if found, _ := contains(container, elem); !found {
+			a.errorMessage("Contains", elem, container, "Container: %+v\nMissing: %+v\n", container, elem)(msg...)
+		}
+	})
+}
+
+// NotContains asserts that the container does not contain the element (see
+// Contains). If the assertion fails the message shows the element:
+//
+//	Error:
+//	  Container: [aaa bbb ccc]
+//	                 ┗━━━┛
+//	  Contains: bbb
+func (a *Assert) NotContains(container, elem interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		found, index := contains(container, elem)
+		if !found {
+			return
+		}
+		text := fmt.Sprintf("Container: %+v\n", container)
+		if index >= 0 {
+			text = markedList("Container", elementsOf(container), []int{index})
+		}
+		a.errorMessage("NotContains", elem, container, "%sContains: %+v\n", text, elem)(msg...)
+	})
+}
+
+// ElementsMatch asserts that the slices or the arrays contain the same
+// elements, in any order. The duplicated elements must have the same count.
+// If the assertion fails the message shows the missing and the extra
+// elements:
+//
+//	Error:
+//	  Exp: [a b c]
+//	         ┗━┛
+//	  Got: [c a d]
+//	           ┗━┛
+//	  Missing: [b]
+//	  Extra: [d]
+func (a *Assert) ElementsMatch(exp, got interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		e, g := elementsOf(exp), elementsOf(got)
+		missing, extra := matchElements(e, g, reflect.DeepEqual)
+		if len(missing) > 0 || len(extra) > 0 {
+			a.errorMessage("ElementsMatch", exp, got, "%s", elementsMessage(e, g, missing, extra))(msg...)
+		}
+	})
+}
+
+// Subset asserts that all the elements of the subset are in the list. The
+// list and the subset are slices, arrays or maps: with maps, the entries of
+// the subset must be in the list (see MapContains).
+func (a *Assert) Subset(list, subset interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if reflect.ValueOf(list).Kind() == reflect.Map {
+			if diff := mapDiff(mapOf(subset), mapOf(list), false); diff != "" {
+				a.errorMessage("Subset", subset, list, "%s", diff)(msg...)
+			}
+			return
+		}
+		l, s := elementsOf(list), elementsOf(subset)
+		if missing := missingElements(l, s, reflect.DeepEqual); len(missing) > 0 {
+			a.errorMessage("Subset", subset, list, "%s", subsetMessage(l, s, missing))(msg...)
+		}
+	})
+}
+
+// Len asserts that the length of the slice, array, map, string or channel is
+// n.
+func (a *Assert) Len(v interface{}, n int, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if l := lengthOf(v); l != n {
+			a.errorMessage("Len", n, l, "Exp length: %d\nGot length: %d\nValue: %+v\n", n, l, v)(msg...)
+		}
+	})
+}
+
+// Empty asserts that v is empty: nil, a slice, array, map, string or channel
+// without element, or a zero value.
+func (a *Assert) Empty(v interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if !isEmpty(v) {
+			a.errorMessage("Empty", nil, v, "Exp: empty\nGot: %+v\n", v)(msg...)
+		}
+	})
+}
+
+// NotEmpty asserts that v is not empty (see Empty).
+func (a *Assert) NotEmpty(v interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if isEmpty(v) {
+			a.errorMessage("NotEmpty", nil, v, "Exp: not empty\nGot: %+v\n", v)(msg...)
+		}
+	})
+}
+
+// contains returns true if the container contains the element, and the
+// index of the element in a slice or an array (-1 otherwise).
+func contains(container, elem interface{}) (bool, int) {
+	v := reflect.ValueOf(container)
+	switch v.Kind() {
+	case reflect.String:
+		s, ok := elem.(string)
+		if !ok {
+			panic(fmt.Sprintf("the element of a string must be a string: %T", elem))
+		}
+		return strings.Contains(v.String(), s), -1
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if reflect.DeepEqual(v.Index(i).Interface(), elem) {
+				return true, i
+			}
+		}
+		return false, -1
+	case reflect.Map:
+		k := reflect.ValueOf(elem)
+		if !k.IsValid() || !k.Type().AssignableTo(v.Type().Key()) {
+			return false, -1
+		}
+		return v.MapIndex(k).IsValid(), -1
+	}
+	panic(fmt.Sprintf("cannot check the elements of %T: a string, slice, array or map is required", container))
+}
+
+// elementsOf returns the elements of the slice or the array v.
+func elementsOf(v interface{}) []interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		panic(fmt.Sprintf("a slice or an array is required: %T", v))
+	}
+	res := make([]interface{}, rv.Len())
+	for i := range res {
+		res[i] = rv.Index(i).Interface()
+	}
+	return res
+}
+
+// lengthOf returns the length of the slice, array, map, string or channel
+// v.
+func lengthOf(v interface{}) int {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
+		return rv.Len()
+	}
+	panic(fmt.Sprintf("cannot get the length of %T", v))
+}
+
+// isEmpty returns true if v is nil, a collection without element or a zero
+// value.
+func isEmpty(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
+
+// matchElements returns the indexes of the elements of exp missing in got
+// and of the extra elements of got. Each element of got matches at most one
+// element of exp.
+func matchElements[T any](exp, got []T, eq func(T, T) bool) (missing, extra []int) {
+	used := make([]bool, len(got))
+	for i, e := range exp {
+		found := false
+		for j, g := range got {
+			if !used[j] && eq(e, g) {
+				used[j], found = true, true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, i)
+		}
+	}
+	for j, u := range used {
+		if !u {
+			extra = append(extra, j)
+		}
+	}
+	return missing, extra
+}
+
+// missingElements returns the indexes of the elements of subset missing in
+// list.
+func missingElements[T any](list, subset []T, eq func(T, T) bool) []int {
+	var missing []int
+	for i, s := range subset {
+		found := false
+		for _, l := range list {
+			if eq(l, s) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, i)
+		}
+	}
+	return missing
+}
+
+// elementsMessage returns the message of ElementsMatch.
+func elementsMessage[T any](exp, got []T, missing, extra []int) string {
+	return markedList("Exp", exp, missing) + markedList("Got", got, extra) +
+		fmt.Sprintf("Missing: %+v\nExtra: %+v\n", pick(exp, missing), pick(got, extra))
+}
+
+// subsetMessage returns the message of Subset.
+func subsetMessage[T any](list, subset []T, missing []int) string {
+	return fmt.Sprintf("List: %+v\n", list) + markedList("Subset", subset, missing) +
+		fmt.Sprintf("Missing: %+v\n", pick(subset, missing))
+}
+
+// pick returns the elements of s at the indexes.
+func pick[T any](s []T, indexes []int) []T {
+	res := make([]T, len(indexes))
+	for i, j := range indexes {
+		res[i] = s[j]
+	}
+	return res
+}
+
+// markedList formats the list of elements with the label, and underlines the
+// elements at the indexes:
+//
+//	Got: [aaa bbb ccc ddd]
+//	         ┗━━━━━━━┛
+func markedList[T any](label string, elems []T, indexes []int) string {
+	parts := formatElements(elems)
+	line := fmt.Sprintf("%s: [%s]\n", label, strings.Join(parts, " "))
+	if len(indexes) == 0 {
+		return line
+	}
+	marked := make([]bool, len(elems))
+	for _, i := range indexes {
+		marked[i] = true
+	}
+	buf := []rune(strings.Repeat(" ", utf8.RuneCountInString(line)))
+	// pos is the column of the separator before the element
+	pos := utf8.RuneCountInString(label) + 2
+	for i, p := range parts {
+		width := utf8.RuneCountInString(p)
+		if marked[i] {
+			if i == 0 || !marked[i-1] {
+				buf[pos] = '┗'
+			} else {
+				buf[pos] = '━'
+			}
+			for c := pos + 1; c <= pos+width; c++ {
+				buf[c] = '━'
+			}
+			if i == len(parts)-1 || !marked[i+1] {
+				buf[pos+width+1] = '┛'
+			}
+		}
+		pos += width + 1
+	}
+	return line + strings.TrimRight(string(buf), " ") + "\n"
+}
+
+// formatElements formats each element like in the format %+v of the list:
+// the pointers in a list are formatted as addresses for example.
+func formatElements[T any](elems []T) []string {
+	res := make([]string, len(elems))
+	for i, e := range elems {
+		s := fmt.Sprintf("%+v", []T{e})
+		res[i] = s[1 : len(s)-1]
+	}
+	return res
+}
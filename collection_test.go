@@ -0,0 +1,117 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestContains(t *testing.T) {
+	a := New(t)
+	isAssert(t, a.Contains("hello world", "o w"))
+	isAssert(t, a.Contains([]string{"a", "b"}, "b"))
+	isAssert(t, a.Contains([2][]int{{1}, {2, 3}}, []int{2, 3}))
+	isAssert(t, a.Contains(map[string]int{"a": 1}, "a"))
+	isAssert(t, a.NotContains("hello", "x"))
+	isAssert(t, a.NotContains([]int{1, 2}, 3))
+	isAssert(t, a.NotContains(map[string]int{"a": 1}, 1))
+
+	b, got := failures(t)
+	b.Contains([]int{1, 2}, 3).NotContains([]string{"aaa", "bbb", "ccc"}, "bbb").NotContains("abc", "b")
+	if len(*got) != 3 {
+		t.Fatalf("got: %d failures, exp: 3", len(*got))
+	}
+	exp := "Container: [aaa bbb ccc]\n               ┗━━━┛\nContains: bbb\n"
+	if !strings.HasSuffix((*got)[1].Text, exp) {
+		t.Errorf("got:\n%s\nexp:\n%s", (*got)[1].Text, exp)
+	}
+}
+
+func TestElementsMatch(t *testing.T) {
+	a := New(t)
+	isAssert(t, a.ElementsMatch([]int{1, 2, 2}, []int{2, 1, 2}))
+	isAssert(t, a.ElementsMatch([]interface{}{"a", []int{1}}, [2]interface{}{[]int{1}, "a"}))
+
+	b, got := failures(t)
+	b.ElementsMatch([]string{"a", "b", "c", "d"}, []string{"d", "x", "y", "a"})
+	exp := "Exp: [a b c d]\n       ┗━━━┛\nGot: [d x y a]\n       ┗━━━┛\nMissing: [b c]\nExtra: [x y]\n"
+	if len(*got) != 1 || !strings.HasSuffix((*got)[0].Text, exp) {
+		t.Errorf("got:\n%v\nexp:\n%s", got, exp)
+	}
+}
+
+func TestSubset(t *testing.T) {
+	a := New(t)
+	isAssert(t, a.Subset([]int{1, 2, 3}, []int{3, 1}))
+	isAssert(t, a.Subset(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2}))
+
+	b, got := failures(t)
+	b.Subset([]int{1, 2}, []int{1, 4})
+	b.Subset(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 3, "c": 1, "a": 1})
+	if len(*got) != 2 {
+		t.Fatalf("got: %d failures, exp: 2", len(*got))
+	}
+	if !strings.HasSuffix((*got)[0].Text, "Subset: [1 4]\n          ┗━┛\nMissing: [4]\n") {
+		t.Errorf("invalid message: %s", (*got)[0].Text)
+	}
+	if !strings.HasSuffix((*got)[1].Text, "Missing keys:\n  \"c\": 1\nChanged keys:\n  \"b\": exp 3 got 2\n") {
+		t.Errorf("invalid message: %s", (*got)[1].Text)
+	}
+}
+
+func TestLenEmpty(t *testing.T) {
+	ch := make(chan int, 2)
+	ch <- 1
+	a := New(t)
+	isAssert(t, a.Len([]int{1, 2}, 2).Len("abc", 3).Len(map[int]int{1: 1}, 1).Len(ch, 1).Len([3]int{}, 3))
+	isAssert(t, a.Empty(nil).Empty("").Empty([]int{}).Empty(map[int]int{}).Empty(0).Empty(make(chan int)))
+	isAssert(t, a.NotEmpty("a").NotEmpty([]int{1}).NotEmpty(ch).NotEmpty(1))
+
+	tb := &fakeTB{TB: t}
+	New(tb).Len([]int{1}, 2)
+	if !tb.failed {
+		t.Errorf("Len failed")
+	}
+	tb = &fakeTB{TB: t}
+	New(tb).Empty([]int{1})
+	if !tb.failed {
+		t.Errorf("Empty failed")
+	}
+	tb = &fakeTB{TB: t}
+	New(tb).NotEmpty(map[int]int{})
+	if !tb.failed {
+		t.Errorf("NotEmpty failed")
+	}
+}
+
+func TestMarkedList(t *testing.T) {
+	got := markedList("Got", []string{"aaa", "bbb", "ccc", "ddd"}, []int{1, 2})
+	exp := "Got: [aaa bbb ccc ddd]\n         ┗━━━━━━━┛\n"
+	if got != exp {
+		t.Errorf("got:\n%s\nexp:\n%s", got, exp)
+	}
+	if got := markedList("Got", []int{1}, nil); got != "Got: [1]\n" {
+		t.Errorf("got: %q", got)
+	}
+	got = markedList("Got", [][]string{{"a", "b"}, {"c"}}, []int{1})
+	if exp := "Got: [[a b] [c]]\n           ┗━━━┛\n"; got != exp {
+		t.Errorf("got:\n%s\nexp:\n%s", got, exp)
+	}
+}
+
+func TestNotContainsPointer(t *testing.T) {
+	p := &mapAddress{City: "Paris", Zip: "75000"}
+	b, got := failures(t)
+	b.NotContains([]*mapAddress{p}, p)
+	NotContainsOf(b, []*mapAddress{p}, p)
+	addr := fmt.Sprintf("%p", p)
+	exp := fmt.Sprintf("Container: [%s]\n           ┗%s┛\nContains: &{City:Paris Zip:75000}\n", addr, strings.Repeat("━", len(addr)))
+	if len(*got) != 2 {
+		t.Fatalf("got: %d failures, exp: 2", len(*got))
+	}
+	for _, f := range *got {
+		if !strings.HasSuffix(f.Text, exp) {
+			t.Errorf("got:\n%s\nexp:\n%s", f.Text, exp)
+		}
+	}
+}
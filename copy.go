@@ -18,10 +18,16 @@ func Cp(src, dest string) error {
 
 // copy ...
 func copy(src, dest string, info os.FileInfo) error {
-	if info.IsDir() {
-		return copyDir(src, dest, info)
-	}
-	return copyFile(src, dest, info)
+	return walk(src, info, func(path string, info os.FileInfo) error {
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dest, rel), info.Mode())
+		}
+		return copyFile(path, filepath.Join(dest, rel), info)
+	})
 }
 
 // copyFile ...
@@ -47,9 +53,10 @@ func copyFile(src, dest string, info os.FileInfo) error {
 	return err
 }
 
-// copyDir ...
-func copyDir(src, dest string, info os.FileInfo) error {
-	if err := os.MkdirAll(dest, info.Mode()); err != nil {
+// walk calls fn for src, then for the files and the directories of src
+// recursively if src is a directory.
+func walk(src string, info os.FileInfo, fn func(path string, info os.FileInfo) error) error {
+	if err := fn(src, info); err != nil || !info.IsDir() {
 		return err
 	}
 
@@ -59,11 +66,7 @@ func copyDir(src, dest string, info os.FileInfo) error {
 	}
 
 	for _, info := range infos {
-		if err := copy(
-			filepath.Join(src, info.Name()),
-			filepath.Join(dest, info.Name()),
-			info,
-		); err != nil {
+		if err := walk(filepath.Join(src, info.Name()), info, fn); err != nil {
 			return err
 		}
 	}
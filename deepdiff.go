@@ -0,0 +1,262 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// maxDiffs is the maximum number of differences showed by a failed assertion.
+const maxDiffs = 20
+
+// visit is a pair of references already compared, to stop on the cycles.
+// The slices sharing the same array are different if their lengths differ.
+type visit struct {
+	exp, got       uintptr
+	expLen, gotLen int
+	typ            reflect.Type
+}
+
+// differ walks two values and records their differences.
+type differ struct {
+	lines   []string
+	total   int
+	visited map[visit]bool
+}
+
+// diffValues returns the differences between exp and got, one difference per
+// line with the path of the value:
+//
+//	.Users[3].Address.Zip: exp "1000" got "1001"
+//
+// The structs, maps, slices, arrays, pointers and interfaces are walked
+// recursively; the number of lines is limited to maxDiffs. It returns nil if
+// the values are equal (as reflect.DeepEqual).
+func diffValues(exp, got interface{}) []string {
+	d := &differ{visited: map[visit]bool{}}
+	d.walk("", reflect.ValueOf(exp), reflect.ValueOf(got))
+	if d.total > maxDiffs {
+		d.lines = append(d.lines, fmt.Sprintf("... and %d more differences", d.total-maxDiffs))
+	}
+	return d.lines
+}
+
+// formatDiffs formats the differences for an error message.
+func formatDiffs(diffs []string) string {
+	return "Differences:\n" + strings.Join(diffs, "\n") + "\n"
+}
+
+// deepMessage returns the error message of two values that are not deeply
+// equal. The values are showed if the differences cannot be found.
+func deepMessage(exp, got interface{}) string {
+	diffs := diffValues(exp, got)
+	if len(diffs) == 0 {
+		return fmt.Sprintf("Exp: %#v\nGot: %#v\n", exp, got)
+	}
+	return formatDiffs(diffs)
+}
+
+// add records a difference.
+func (d *differ) add(path string, format string, args ...interface{}) {
+	d.total++
+	if d.total > maxDiffs {
+		return
+	}
+	if path == "" {
+		path = "."
+	}
+	d.lines = append(d.lines, path+": "+fmt.Sprintf(format, args...))
+}
+
+// mismatch records two different values.
+func (d *differ) mismatch(path string, exp, got reflect.Value) {
+	d.add(path, "exp %s got %s", formatValue(exp), formatValue(got))
+}
+
+// seen returns true if the references have been already compared.
+func (d *differ) seen(exp, got reflect.Value) bool {
+	v := visit{exp: exp.Pointer(), got: got.Pointer(), typ: exp.Type()}
+	if exp.Kind() == reflect.Slice {
+		v.expLen, v.gotLen = exp.Len(), got.Len()
+	}
+	if d.visited[v] {
+		return true
+	}
+	d.visited[v] = true
+	return false
+}
+
+func (d *differ) walk(path string, exp, got reflect.Value) {
+	if !exp.IsValid() || !got.IsValid() {
+		if exp.IsValid() != got.IsValid() {
+			d.mismatch(path, exp, got)
+		}
+		return
+	}
+	if exp.Type() != got.Type() {
+		d.add(path, "exp %s (%s) got %s (%s)", formatValue(exp), exp.Type(), formatValue(got), got.Type())
+		return
+	}
+
+	switch exp.Kind() {
+	case reflect.Ptr:
+		if exp.IsNil() || got.IsNil() {
+			if exp.IsNil() != got.IsNil() {
+				d.mismatch(path, exp, got)
+			}
+			return
+		}
+		if exp.Pointer() == got.Pointer() || d.seen(exp, got) {
+			return
+		}
+		d.walk(path, exp.Elem(), got.Elem())
+
+	case reflect.Interface:
+		if exp.IsNil() || got.IsNil() {
+			if exp.IsNil() != got.IsNil() {
+				d.mismatch(path, exp, got)
+			}
+			return
+		}
+		d.walk(path, exp.Elem(), got.Elem())
+
+	case reflect.Struct:
+		for i := 0; i < exp.NumField(); i++ {
+			d.walk(path+"."+exp.Type().Field(i).Name, exp.Field(i), got.Field(i))
+		}
+
+	case reflect.Slice:
+		if exp.IsNil() != got.IsNil() {
+			d.mismatch(path, exp, got)
+			return
+		}
+		if exp.Len() == got.Len() && exp.Pointer() == got.Pointer() {
+			return
+		}
+		if exp.Len() > 0 && got.Len() > 0 && d.seen(exp, got) {
+			return
+		}
+		d.walkList(path, exp, got)
+
+	case reflect.Array:
+		d.walkList(path, exp, got)
+
+	case reflect.Map:
+		if exp.IsNil() != got.IsNil() {
+			d.mismatch(path, exp, got)
+			return
+		}
+		if exp.Pointer() == got.Pointer() || d.seen(exp, got) {
+			return
+		}
+		d.walkMap(path, exp, got)
+
+	case reflect.Func:
+		if !exp.IsNil() || !got.IsNil() {
+			d.add(path, "functions are not comparable")
+		}
+
+	case reflect.Chan, reflect.UnsafePointer:
+		if exp.Pointer() != got.Pointer() {
+			d.mismatch(path, exp, got)
+		}
+
+	case reflect.Bool:
+		if exp.Bool() != got.Bool() {
+			d.mismatch(path, exp, got)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if exp.Int() != got.Int() {
+			d.mismatch(path, exp, got)
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if exp.Uint() != got.Uint() {
+			d.mismatch(path, exp, got)
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if exp.Float() != got.Float() {
+			d.mismatch(path, exp, got)
+		}
+
+	case reflect.Complex64, reflect.Complex128:
+		if exp.Complex() != got.Complex() {
+			d.mismatch(path, exp, got)
+		}
+
+	case reflect.String:
+		if exp.String() != got.String() {
+			d.mismatch(path, exp, got)
+		}
+	}
+}
+
+// walkList compares the elements of two slices or arrays.
+func (d *differ) walkList(path string, exp, got reflect.Value) {
+	for i := 0; i < exp.Len() || i < got.Len(); i++ {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= got.Len():
+			d.add(p, "missing %s", formatValue(exp.Index(i)))
+		case i >= exp.Len():
+			d.add(p, "unexpected %s", formatValue(got.Index(i)))
+		default:
+			d.walk(p, exp.Index(i), got.Index(i))
+		}
+	}
+}
+
+// walkMap compares the values of two maps, the keys are sorted.
+func (d *differ) walkMap(path string, exp, got reflect.Value) {
+	keys := exp.MapKeys()
+	for _, k := range got.MapKeys() {
+		if !exp.MapIndex(k).IsValid() {
+			keys = append(keys, k)
+		}
+	}
+	sortValues(keys)
+	for _, k := range keys {
+		p := fmt.Sprintf("%s[%s]", path, formatValue(k))
+		e, g := exp.MapIndex(k), got.MapIndex(k)
+		switch {
+		case !g.IsValid():
+			d.add(p, "missing %s", formatValue(e))
+		case !e.IsValid():
+			d.add(p, "unexpected %s", formatValue(g))
+		default:
+			d.walk(p, e, g)
+		}
+	}
+}
+
+// formatValue formats a value with the Go syntax.
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
+// sortValues sorts the values (map keys) in the natural order for the numbers
+// and the strings, else in the order of their Go representations.
+func sortValues(values []reflect.Value) {
+	sort.Slice(values, func(i, j int) bool {
+		x, y := values[i], values[j]
+		if x.Kind() == y.Kind() {
+			switch x.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				return x.Int() < y.Int()
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				return x.Uint() < y.Uint()
+			case reflect.Float32, reflect.Float64:
+				return x.Float() < y.Float()
+			case reflect.String:
+				return x.String() < y.String()
+			}
+		}
+		return formatValue(x) < formatValue(y)
+	})
+}
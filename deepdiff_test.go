@@ -0,0 +1,90 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+type diffAddress struct {
+	Zip string
+}
+
+type diffUser struct {
+	Name    string
+	Address *diffAddress
+	Tags    map[string]int
+	private []int
+}
+
+type diffNode struct {
+	Value int
+	Next  *diffNode
+}
+
+func TestDiffValues(t *testing.T) {
+	exp := struct{ Users []diffUser }{[]diffUser{
+		{Name: "a", Address: &diffAddress{"1000"}, Tags: map[string]int{"x": 1, "y": 2}, private: []int{1}},
+		{Name: "b"},
+	}}
+	got := struct{ Users []diffUser }{[]diffUser{
+		{Name: "a", Address: &diffAddress{"1001"}, Tags: map[string]int{"x": 1, "z": 3}, private: []int{2}},
+	}}
+	diffs := diffValues(exp, got)
+	expDiffs := []string{
+		`.Users[0].Address.Zip: exp "1000" got "1001"`,
+		`.Users[0].Tags["y"]: missing 2`,
+		`.Users[0].Tags["z"]: unexpected 3`,
+		`.Users[0].private[0]: exp 1 got 2`,
+		`.Users[1]: missing assert.diffUser{Name:"b", Address:(*assert.diffAddress)(nil), Tags:map[string]int(nil), private:[]int(nil)}`,
+	}
+	if strings.Join(diffs, "\n") != strings.Join(expDiffs, "\n") {
+		t.Errorf("got:\n%s\nexp:\n%s", strings.Join(diffs, "\n"), strings.Join(expDiffs, "\n"))
+	}
+}
+
+func TestDiffValuesEqual(t *testing.T) {
+	a := &diffNode{Value: 1}
+	a.Next = a
+	b := &diffNode{Value: 1}
+	b.Next = b
+	if diffs := diffValues(a, b); diffs != nil {
+		t.Errorf("unexpected differences: %s", diffs)
+	}
+	if diffs := diffValues(map[int][]int{1: {2}}, map[int][]int{1: {2}}); diffs != nil {
+		t.Errorf("unexpected differences: %s", diffs)
+	}
+}
+
+func TestDiffValuesAliasedSlices(t *testing.T) {
+	x, y := []int{1, 2, 3}, []int{1, 2, 4}
+	exp := [][]int{x[:2], x[:3]}
+	got := [][]int{y[:2], y[:3]}
+	diffs := diffValues(exp, got)
+	if len(diffs) != 1 || diffs[0] != "[1][2]: exp 3 got 4" {
+		t.Errorf("got: %s", diffs)
+	}
+	tb := &fakeTB{TB: t}
+	New(tb).NotEqualDeep(exp, got)
+	if tb.failed {
+		t.Errorf("NotEqualDeep failed")
+	}
+}
+
+func TestDiffValuesTypes(t *testing.T) {
+	diffs := diffValues(3, int64(3))
+	if len(diffs) != 1 || diffs[0] != ".: exp 3 (int) got 3 (int64)" {
+		t.Errorf("got: %s", diffs)
+	}
+}
+
+func TestDiffValuesMax(t *testing.T) {
+	exp := make([]int, 100)
+	got := make([]int, 100)
+	for i := range got {
+		got[i] = 1
+	}
+	diffs := diffValues(exp, got)
+	if len(diffs) != maxDiffs+1 || diffs[maxDiffs] != "... and 80 more differences" {
+		t.Errorf("got: %s", diffs)
+	}
+}
@@ -0,0 +1,256 @@
+package assert
+
+import (
+	"sync"
+	"testing"
+)
+
+// Describe groups the subtests in a new subtest. The hooks defined in fn
+// with BeforeEach and AfterEach are executed around every subtest of the
+// group:
+//
+//	a.Describe("Stack", func(a *T.Assert) {
+//		var s *Stack
+//		a.BeforeEach(func(a *T.Assert) {
+//			s = NewStack()
+//		})
+//		a.It("is empty", func(a *T.Assert) {
+//			a.Equal(0, s.Len())
+//		})
+//		a.When("with one item", func(a *T.Assert) {
+//			a.BeforeEach(func(a *T.Assert) {
+//				s.Push(1)
+//			})
+//			a.It("has one item", func(a *T.Assert) {
+//				a.Equal(1, s.Len())
+//			})
+//		})
+//	})
+//
+// fn is executed immediately to define the subtests and the nested groups,
+// the assertions must be in the subtests. The subtests are executed after fn,
+// in definition order.
+func (a *Assert) Describe(msg string, fn func(*Assert)) *Assert {
+	c := a.clone(a.t)
+	g := &group{}
+	c.group = g
+	fn(c)
+	return a.schedule(groupTest{sub: g, start: func(parent testing.TB, skip string) {
+		run(parent, msg, func(t testing.TB) {
+			c.t = t
+			if skip != "" {
+				c.Skip(skip)
+			}
+			g.flush(c)
+		})
+	}})
+}
+
+// When is an alias of Describe, to group the subtests of a condition.
+func (a *Assert) When(msg string, fn func(*Assert)) *Assert {
+	return a.Describe(msg, fn)
+}
+
+// BeforeEach registers a hook executed before every subtest (It, ItTmp,
+// ItEnv...) defined after it with this Assert. The hooks are executed in
+// definition order, the hooks of the parent groups first.
+func (a *Assert) BeforeEach(fn func(*Assert)) *Assert {
+	return a.set(func(c *config) {
+		c.before = append(c.before[:len(c.before):len(c.before)], fn)
+	})
+}
+
+// AfterEach registers a hook executed after every subtest (It, ItTmp,
+// ItEnv...) defined after it with this Assert. The hooks are executed in
+// definition order, the hooks of the parent groups first, even if the subtest
+// or a hook fails with a fatal error.
+func (a *Assert) AfterEach(fn func(*Assert)) *Assert {
+	return a.set(func(c *config) {
+		c.after = append(c.after[:len(c.after):len(c.after)], fn)
+	})
+}
+
+// BeforeAll executes fn once before the subtests of the group, or
+// immediately outside of a group.
+func (a *Assert) BeforeAll(fn func(*Assert)) *Assert {
+	if a.group.immediate {
+		fn(a)
+		return a
+	}
+	a.group.mu.Lock()
+	defer a.group.mu.Unlock()
+	a.group.before = append(a.group.before, fn)
+	return a
+}
+
+// AfterAll executes fn when the test or the group and all its subtests
+// (including the parallel subtests) are completed.
+func (a *Assert) AfterAll(fn func(*Assert)) *Assert {
+	if a.group.immediate {
+		a.t.Cleanup(func() { fn(a) })
+		return a
+	}
+	a.group.mu.Lock()
+	defer a.group.mu.Unlock()
+	a.group.after = append(a.group.after, fn)
+	return a
+}
+
+// hooks wraps fn with the BeforeEach and AfterEach hooks of the Assert. The
+// hooks are removed from the Assert: they are not executed again around the
+// subtests of fn.
+func (a *Assert) hooks(fn func(*Assert)) func(*Assert) {
+	var before, after []func(*Assert)
+	a.set(func(c *config) {
+		before, after = c.before, c.after
+		c.before, c.after = nil, nil
+	})
+	if len(before) == 0 && len(after) == 0 {
+		return fn
+	}
+	return func(a *Assert) {
+		for i := len(after) - 1; i >= 0; i-- {
+			defer after[i](a)
+		}
+		for _, h := range before {
+			h(a)
+		}
+		fn(a)
+	}
+}
+
+// FIt is similar to It but the subtest is focused: the subtests of the test
+// that are not focused are skipped, a group is executed if it contains a
+// focused subtest. In a Describe group, the focus applies to all the
+// subtests of the group. Outside of a group, the subtests are executed in
+// definition order, so the subtests defined before the focused subtest are
+// already executed: the test fails, the focused subtests must be defined
+// first or in a group.
+//
+// To prevent to commit the focused subtests, the test fails if the
+// environment variable GO_ASSERT_CI or CI is set to true.
+func (a *Assert) FIt(msg string, fn func(*Assert)) *Assert {
+	if onCI() {
+		a.errorMessage("FIt", nil, nil, "Focused subtest %q on CI\n", msg)()
+	}
+	return a.define(msg, false, true, "", fn)
+}
+
+// onCI returns true if the tests are executed on CI: the environment
+// variable GO_ASSERT_CI or CI is set to true.
+func onCI() bool {
+	return envBool("GO_ASSERT_CI") || envBool("CI")
+}
+
+// XIt is similar to It but the subtest is disabled: it is reported as
+// skipped.
+func (a *Assert) XIt(msg string, fn func(*Assert)) *Assert {
+	return a.define(msg, false, false, "disabled subtest", fn)
+}
+
+// Pending defines a subtest not yet implemented: it is reported as skipped
+// with the reason.
+func (a *Assert) Pending(msg string, reason string) *Assert {
+	return a.define(msg, false, false, "pending: "+reason, func(*Assert) {})
+}
+
+// group contains the subtests of a Describe group, executed at the end of
+// the group to know the focused subtests. The subtests of an immediate group
+// (a test or a subtest outside of Describe) are executed at their
+// definition.
+type group struct {
+	mu        sync.Mutex
+	immediate bool
+	tests     []groupTest
+	before    []func(*Assert)
+	after     []func(*Assert)
+	// focused is true if an immediate group executes only the focused
+	// subtests, executed is the number of subtests executed before.
+	focused  bool
+	executed int
+}
+
+// groupTest is a subtest or a nested group (sub) of a group. start executes
+// it in a subtest of parent, skipped with the reason skip if it is not empty.
+type groupTest struct {
+	focus bool
+	skip  string
+	sub   *group
+	start func(parent testing.TB, skip string)
+}
+
+// focused returns true if the subtest is focused or if the group contains a
+// focused subtest.
+func (t groupTest) focused() bool {
+	return t.focus || t.sub != nil && t.sub.hasFocus()
+}
+
+// hasFocus returns true if the group contains a focused subtest.
+func (g *group) hasFocus() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, t := range g.tests {
+		if t.focused() {
+			return true
+		}
+	}
+	return false
+}
+
+// schedule executes the subtest immediately or adds it in the group of the
+// Assert.
+func (a *Assert) schedule(t groupTest) *Assert {
+	g := a.group
+	g.mu.Lock()
+	if !g.immediate {
+		g.tests = append(g.tests, t)
+		g.mu.Unlock()
+		return a
+	}
+	executed := 0
+	switch {
+	case t.focused():
+		if !g.focused {
+			g.focused, executed = true, g.executed
+		}
+	case t.skip != "":
+	case g.focused:
+		t.skip = "not focused subtest"
+	default:
+		g.executed++
+	}
+	g.mu.Unlock()
+	if executed > 0 {
+		a.errorMessage("FIt", nil, nil, "Focused subtest defined after %d subtests already executed: define it first or in a Describe group\n", executed)()
+	}
+	t.start(a.t, t.skip)
+	return a
+}
+
+// flush executes the hooks BeforeAll and AfterAll and the subtests of the
+// group with the Assert of the group. If at least one subtest is focused,
+// the other subtests are skipped.
+func (g *group) flush(a *Assert) {
+	g.mu.Lock()
+	tests, before, after := g.tests, g.before, g.after
+	g.tests = nil
+	g.mu.Unlock()
+	for _, h := range after {
+		h := h
+		a.t.Cleanup(func() { h(a) })
+	}
+	for _, h := range before {
+		h(a)
+	}
+	focus := false
+	for _, t := range tests {
+		focus = focus || t.focused()
+	}
+	for _, t := range tests {
+		skip := t.skip
+		if skip == "" && focus && !t.focused() {
+			skip = "not focused subtest"
+		}
+		t.start(a.t, skip)
+	}
+}
@@ -0,0 +1,158 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	var got []string
+	log := func(s string) func(*Assert) {
+		return func(*Assert) { got = append(got, s) }
+	}
+	a := New(t)
+	isAssert(t, a.Describe("group", func(a *Assert) {
+		a.BeforeAll(log("before all")).AfterAll(log("after all"))
+		a.BeforeEach(log("before 1")).BeforeEach(log("before 2")).AfterEach(log("after"))
+		a.It("test 1", func(a *Assert) {
+			got = append(got, "test 1")
+			a.It("nested", log("nested"))
+		})
+		a.When("context", func(a *Assert) {
+			a.BeforeEach(log("before 3"))
+			a.ItTmp("test 2", func(a *Assert, dir string) { got = append(got, "test 2") })
+		})
+	}))
+	exp := []string{
+		"before all",
+		"before 1", "before 2", "test 1", "nested", "after",
+		"before 1", "before 2", "before 3", "test 2", "after",
+		"after all",
+	}
+	if strings.Join(got, ",") != strings.Join(exp, ",") {
+		t.Errorf("got: %v\nexp: %v", got, exp)
+	}
+	got = nil
+	a.It("outside", log("outside"))
+	if strings.Join(got, ",") != "outside" {
+		t.Errorf("hooks executed outside the group: %v", got)
+	}
+}
+
+func TestAfterEachFatal(t *testing.T) {
+	var got []string
+	a := New(t).
+		AfterEach(func(*Assert) { got = append(got, "after 1") }).
+		AfterEach(func(*Assert) { got = append(got, "after 2") })
+	failures, ok := a.record(func(a *Assert) {
+		a.hooks(func(a *Assert) {
+			a.SetFatal(true).True(false)
+			got = append(got, "not executed")
+		})(a)
+	})
+	if ok || len(failures) != 1 {
+		t.Errorf("invalid failures: %v", failures)
+	}
+	if strings.Join(got, ",") != "after 1,after 2" {
+		t.Errorf("got: %v", got)
+	}
+}
+
+func TestFocus(t *testing.T) {
+	t.Setenv("CI", "")
+	t.Setenv("GO_ASSERT_CI", "")
+	var got []string
+	log := func(s string) func(*Assert) {
+		return func(*Assert) { got = append(got, s) }
+	}
+	New(t).Describe("group", func(a *Assert) {
+		a.It("test 1", log("test 1"))
+		a.FIt("test 2", log("test 2"))
+		a.When("context", func(a *Assert) {
+			a.It("test 3", log("test 3"))
+		})
+		a.FIt("test 4", func(a *Assert) {
+			a.It("nested", log("nested"))
+		})
+		a.XIt("test 5", log("test 5"))
+	})
+	if strings.Join(got, ",") != "test 2,nested" {
+		t.Errorf("got: %v", got)
+	}
+
+	got = nil
+	New(t).Describe("group", func(a *Assert) {
+		a.It("test 1", log("test 1"))
+		a.XIt("test 2", log("test 2"))
+		a.Pending("test 3", "not implemented")
+	})
+	if strings.Join(got, ",") != "test 1" {
+		t.Errorf("got: %v", got)
+	}
+
+	got = nil
+	New(t).Describe("group", func(a *Assert) {
+		a.BeforeAll(log("before all"))
+		a.It("test 1", log("test 1"))
+		a.When("context 1", func(a *Assert) {
+			a.BeforeAll(log("not executed"))
+			a.It("test 2", log("test 2"))
+		})
+		a.When("context 2", func(a *Assert) {
+			a.It("test 3", log("test 3"))
+			a.When("context 3", func(a *Assert) {
+				a.FIt("test 4", log("test 4"))
+			})
+		})
+	})
+	if strings.Join(got, ",") != "before all,test 4" {
+		t.Errorf("got: %v", got)
+	}
+}
+
+func TestFocusTest(t *testing.T) {
+	t.Setenv("CI", "false")
+	t.Setenv("GO_ASSERT_CI", "")
+	var got []string
+	log := func(s string) func(*Assert) {
+		return func(*Assert) { got = append(got, s) }
+	}
+	a := New(t)
+	a.XIt("test 1", log("test 1"))
+	a.FIt("test 2", log("test 2"))
+	a.It("test 3", log("test 3"))
+	a.Describe("group", func(a *Assert) {
+		a.It("test 4", log("test 4"))
+	})
+	a.Describe("focused group", func(a *Assert) {
+		a.It("test 5", log("test 5"))
+		a.FIt("test 6", log("test 6"))
+	})
+	if strings.Join(got, ",") != "test 2,test 6" {
+		t.Errorf("got: %v", got)
+	}
+
+	var skips []string
+	b, reported := failures(t)
+	start := func(_ testing.TB, skip string) { skips = append(skips, skip) }
+	b.schedule(groupTest{start: start}).
+		schedule(groupTest{focus: true, start: start}).
+		schedule(groupTest{start: start})
+	if len(*reported) != 1 || !strings.Contains((*reported)[0].Text, "Focused subtest defined after 1 subtests already executed") {
+		t.Errorf("invalid failures: %v", *reported)
+	}
+	if strings.Join(skips, ",") != ",,not focused subtest" {
+		t.Errorf("got: %q", skips)
+	}
+}
+
+func TestFocusCI(t *testing.T) {
+	t.Setenv("CI", "true")
+	tb := &fakeTB{TB: t}
+	a := New(tb)
+	a.group = &group{}
+	a.FIt("focused", func(*Assert) {})
+	if !tb.failed {
+		t.Errorf("focused subtest allowed on CI")
+	}
+}
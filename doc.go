@@ -27,11 +27,23 @@ You could customize the behavior of the library with the environment variables:
 	- GO_ASSERT_FATAL: uses fatal errors
 	- GO_ASSERT_TMP_DISABLE: disable the deletion of temporary directory with ItTmp and ItEnv
 	- GO_ASSERT_OS: test for a specific OS (the possible values are similar to runtime.GOOS)
+	- GO_ASSERT_REPORT: writes a JUnit XML (junit) or a JSON (json) report file of the package
+	- GO_ASSERT_REPORT_DIR: directory of the report file (by default the package directory)
+	- GO_ASSERT_DIFF_CONTEXT: number of context lines of the multi-line string diffs (3 by default)
+	- GO_ASSERT_UPDATE: writes the got values in the golden files of EqualFile instead of comparing them
+	- GO_ASSERT_UPDATE_BASELINE: writes the measures in the baseline files of Baseline instead of comparing them
+	- GO_ASSERT_CI (or CI): fails the tests with focused subtests (FIt) if it is true
+	- GO_ASSERT_SEED: seed of the values generated by Property to replay a failure
 
 or with the NewCustom constructor.
 
 	a := T.NewCustom(t, true, false)
 
+The failures are sent to the reporters given to the constructors, by default
+the LogReporter that writes in the test log:
+
+	a := T.New(t, T.LogReporter{}, myReporter)
+
 
 Examples
 
@@ -2,15 +2,71 @@ package assert
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"reflect"
+	"sync"
 	"syscall"
 	"testing"
 )
 
+// run executes fn in a subtest of t.
+//
+// With a testing.B, fn is executed only once even if the benchmark framework
+// calls the sub-benchmark several times.
+func run(t testing.TB, name string, fn func(testing.TB)) bool {
+	if r, ok := t.(*timeoutTB); ok {
+		// the subtest has its own goroutine
+		t = r.TB
+	}
+	fn = recordSkip(fn)
+	if fileReports.enabled() {
+		fn = fileReports.wrap(fn)
+	}
+	switch v := t.(type) {
+	case *testing.T:
+		return v.Run(name, func(t *testing.T) { fn(t) })
+	case *testing.B:
+		var once sync.Once
+		return v.Run(name, func(b *testing.B) {
+			once.Do(func() { fn(b) })
+		})
+	case *recorder:
+		t.Fatalf("subtests are not supported in the recorded functions (Eventually, Property...): %s", name)
+		return false
+	}
+	t.Fatalf("subtests are not supported with %T", t)
+	return false
+}
+
+// fuzzAdapter converts a function func(*Assert, args...) into a fuzz target
+// func(*testing.T, args...) accepted by testing.F.Fuzz.
+func fuzzAdapter(a *Assert, fn interface{}) interface{} {
+	v := reflect.ValueOf(fn)
+	typ := v.Type()
+	if typ.Kind() != reflect.Func || typ.NumIn() == 0 || typ.In(0) != reflect.TypeOf(a) {
+		panic(fmt.Sprintf("fuzz target must be a func(*Assert, ...): %T", fn))
+	}
+	in := []reflect.Type{reflect.TypeOf((*testing.T)(nil))}
+	for i := 1; i < typ.NumIn(); i++ {
+		in = append(in, typ.In(i))
+	}
+	ft := reflect.FuncOf(in, nil, false)
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		// the fatal mode of the Assert is kept: FailNow stops the input and
+		// the fuzzer records it as a failing input
+		b := a.dup()
+		b.t = args[0].Interface().(*testing.T)
+		args[0] = reflect.ValueOf(b)
+		v.Call(args)
+		return nil
+	}).Interface()
+}
+
 // tmpDir creates a temporary directory.
 func tmpDir(fn func(dir string)) {
 	dir, err := ioutil.TempDir("", "go-testing-")
@@ -25,8 +81,13 @@ func tmpDir(fn func(dir string)) {
 	fn(dir)
 }
 
-// captureOutput captures the standard output.
-func captureOutput(fn func()) (string, string) {
+// captureMu prevents the concurrent captures of the standard output.
+var captureMu sync.Mutex
+
+// captureOutput captures the standard output. captureMu must be locked, it is
+// unlocked when the standard output and error are restored: at the end of fn,
+// even if fn panics, or when ctx is canceled (timeout of the subtest).
+func captureOutput(ctx context.Context, fn func()) (string, string) {
 	oldOut := os.Stdout
 	oldErr := os.Stderr
 
@@ -34,31 +95,47 @@ func captureOutput(fn func()) (string, string) {
 	re, we, _ := os.Pipe()
 	os.Stdout = wo
 	os.Stderr = we
-	fn()
-	outC := make(chan string)
-	errC := make(chan string)
 
-	// copy the output in a separate goroutine so printing can't block indefinitely
+	var once sync.Once
+	restore := func() {
+		once.Do(func() {
+			wo.Close()
+			we.Close()
+			os.Stdout = oldOut // restoring the real stdout
+			os.Stderr = oldErr // restoring the real stderr
+			captureMu.Unlock()
+		})
+	}
+	defer restore()
+	done := make(chan struct{})
+	defer close(done)
 	go func() {
-		var outB, errB bytes.Buffer
-		io.Copy(&outB, ro)
-		io.Copy(&errB, re)
-		outC <- outB.String()
-		errC <- errB.String()
+		select {
+		case <-ctx.Done():
+			restore()
+		case <-done:
+		}
 	}()
 
-	// back to normal state
-	wo.Close()
-	we.Close()
-	os.Stdout = oldOut // restoring the real stdout
-	os.Stderr = oldErr // restoring the real stdout
-	out := <-outC
-	err := <-errC
-	return out, err
+	// copy the output in separate goroutines so printing can't block indefinitely
+	read := func(r *os.File) chan string {
+		c := make(chan string, 1)
+		go func() {
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+			r.Close()
+			c <- buf.String()
+		}()
+		return c
+	}
+	outC, errC := read(ro), read(re)
+	fn()
+	restore()
+	return <-outC, <-errC
 }
 
 // crashTest captures the return code of functions that uses os.Exit.
-func crashTest(t *testing.T, fn func()) (int, string, string) {
+func crashTest(t testing.TB, fn func()) (int, string, string) {
 	// The forked process executes only fn and exit.
 	if os.Getenv("GO_TESTING_CRASH_TEST") == "1" {
 		fn()
@@ -67,7 +144,11 @@ func crashTest(t *testing.T, fn func()) (int, string, string) {
 
 	// Fork the process and run the function in this process.
 	var outBuf, errBuf bytes.Buffer
-	cmd := exec.Command(os.Args[0], fmt.Sprintf("-test.run=^%s$", t.Name()))
+	args := []string{fmt.Sprintf("-test.run=^%s$", t.Name())}
+	if _, ok := t.(*testing.B); ok {
+		args = []string{"-test.run=^$", fmt.Sprintf("-test.bench=^%s$", t.Name())}
+	}
+	cmd := exec.Command(os.Args[0], args...)
 	cmd.Env = append(os.Environ(), "GO_TESTING_CRASH_TEST=1")
 	cmd.Stdout = &outBuf
 	cmd.Stderr = &errBuf
@@ -0,0 +1,112 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// ErrorIs asserts that an error of the chain of err matches target with
+// errors.Is.
+//
+// If the assertion fails then a message shows the chain of the errors:
+//
+//	Error:
+//	  Error chain does not match: file does not exist
+//	  Chain:
+//	    *fmt.wrapError: read config: permission denied
+//	    *fs.PathError: open config.yml: permission denied
+//	    syscall.Errno: permission denied
+func (a *Assert) ErrorIs(err, target error, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if !errors.Is(err, target) {
+			a.errorMessage("ErrorIs", target, err, "Error chain does not match: %v\n%s", target, errorChain(err))(msg...)
+		}
+	})
+}
+
+// ErrorAs asserts that an error of the chain of err can be assigned to target
+// with errors.As. target must be a non-nil pointer to an error type or to an
+// interface.
+//
+// Example:
+//
+//	var pathErr *fs.PathError
+//	a.ErrorAs(err, &pathErr).Equal("open", pathErr.Op)
+func (a *Assert) ErrorAs(err error, target interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if !errors.As(err, target) {
+			typ := reflect.TypeOf(target).Elem()
+			a.errorMessage("ErrorAs", typ, err, "No error of type %s in the chain\n%s", typ, errorChain(err))(msg...)
+		}
+	})
+}
+
+// ErrorContains asserts that the message of err contains substr.
+func (a *Assert) ErrorContains(err error, substr string, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if err == nil {
+			a.errorMessage("ErrorContains", substr, err, "Expected error containing: %s", substr)(msg...)
+			return
+		}
+		if !strings.Contains(err.Error(), substr) {
+			a.errorMessage("ErrorContains", substr, err, "Error message does not contain: %s\n%s", substr, errorChain(err))(msg...)
+		}
+	})
+}
+
+// ErrorMatch asserts that the message of err matches the regex pattern.
+func (a *Assert) ErrorMatch(err error, pattern string, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if err == nil {
+			a.errorMessage("ErrorMatch", pattern, err, "Expected error matching: %s", pattern)(msg...)
+			return
+		}
+		m, rerr := regexp.MatchString(pattern, err.Error())
+		if rerr != nil {
+			panic(rerr)
+		}
+		if !m {
+			a.errorMessage("ErrorMatch", pattern, err, "Regex (%s) mismatch\n%s", pattern, errorChain(err))(msg...)
+		}
+	})
+}
+
+// NoError asserts that err is nil.
+func (a *Assert) NoError(err error, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if err != nil {
+			a.errorMessage("NoError", nil, err, "Unexpected error\n%s", errorChain(err))(msg...)
+		}
+	})
+}
+
+// errorChain formats the errors of the chain of err (errors.Unwrap) with their
+// types and their messages, one error per line.
+func errorChain(err error) string {
+	var buf strings.Builder
+	buf.WriteString("Chain:\n")
+	if err == nil {
+		buf.WriteString("  <nil>\n")
+		return buf.String()
+	}
+	writeChain(&buf, err, 1)
+	return buf.String()
+}
+
+// writeChain writes the error and the errors wrapped by it. The errors joined
+// (Unwrap() []error) are indented.
+func writeChain(buf *strings.Builder, err error, depth int) {
+	for err != nil {
+		fmt.Fprintf(buf, "%s%T: %s\n", strings.Repeat("  ", depth), err, err.Error())
+		if multi, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range multi.Unwrap() {
+				writeChain(buf, e, depth+1)
+			}
+			return
+		}
+		err = errors.Unwrap(err)
+	}
+}
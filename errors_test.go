@@ -0,0 +1,61 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+type joinError []error
+
+func (e joinError) Error() string   { return fmt.Sprintf("%s, %s", e[0], e[1]) }
+func (e joinError) Unwrap() []error { return e }
+
+func TestAssertErrorIs(t *testing.T) {
+	_, err := os.Open("/file/not/exist")
+	isAssert(t, New(t).ErrorIs(fmt.Errorf("wrap: %w", err), fs.ErrNotExist))
+}
+
+func TestAssertErrorAs(t *testing.T) {
+	_, err := os.Open("/file/not/exist")
+	var pathErr *fs.PathError
+	isAssert(t, New(t).ErrorAs(fmt.Errorf("wrap: %w", err), &pathErr).Equal("open", pathErr.Op))
+}
+
+func TestAssertErrorContains(t *testing.T) {
+	isAssert(t, New(t).ErrorContains(errors.New("my message 33"), "message"))
+}
+
+func TestAssertErrorMatch(t *testing.T) {
+	isAssert(t, New(t).ErrorMatch(errors.New("my message 33"), `message \d+$`))
+}
+
+func TestAssertNoError(t *testing.T) {
+	isAssert(t, New(t).NoError(nil))
+}
+
+func TestErrorChain(t *testing.T) {
+	err := fmt.Errorf("read config: %w", &fs.PathError{Op: "open", Path: "a", Err: fs.ErrPermission})
+	exp := "Chain:\n" +
+		"  *fmt.wrapError: read config: open a: permission denied\n" +
+		"  *fs.PathError: open a: permission denied\n" +
+		"  *errors.errorString: permission denied\n"
+	if got := errorChain(err); got != exp {
+		t.Errorf("got:\n%s\nexp:\n%s", got, exp)
+	}
+	joined := joinError{errors.New("a"), errors.New("b")}
+	exp = "Chain:\n  assert.joinError: a, b\n    *errors.errorString: a\n    *errors.errorString: b\n"
+	if got := errorChain(joined); got != exp {
+		t.Errorf("got:\n%q\nexp:\n%q", got, exp)
+	}
+}
+
+func TestErrorFailure(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	New(tb).NoError(errors.New("boom"))
+	if !tb.failed {
+		t.Errorf("NoError not failed")
+	}
+}
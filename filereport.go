@@ -0,0 +1,345 @@
+package assert
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fileReport collects the tests and the failures to write the report file of
+// the package, enabled with the environment variable GO_ASSERT_REPORT:
+//   - GO_ASSERT_REPORT=junit: writes a JUnit XML file <package>.xml
+//   - GO_ASSERT_REPORT=json: writes a JSON file <package>.json
+//
+// The file is written in the directory GO_ASSERT_REPORT_DIR (by default the
+// directory of the package) after each test. The name of the file is the
+// import path of the package, with the slashes replaced by underscores.
+type fileReport struct {
+	mu     sync.Mutex
+	err    error
+	format string
+	dir    string
+	pkg    string
+	cases  []*reportCase
+	names  map[string]*reportCase
+	roots  map[string]bool
+}
+
+// reportCase is a test or a subtest of the report.
+type reportCase struct {
+	name     string
+	start    time.Time
+	duration time.Duration
+	failed   bool
+	skipped  bool
+	done     bool
+	failures []Failure
+}
+
+var fileReports = newFileReport(os.Getenv("GO_ASSERT_REPORT"), os.Getenv("GO_ASSERT_REPORT_DIR"))
+
+// newFileReport creates the report of the format. An invalid format is
+// reported by root, in the first test of the package.
+func newFileReport(format, dir string) *fileReport {
+	var err error
+	if format != "" && format != "junit" && format != "json" {
+		err = fmt.Errorf("GO_ASSERT_REPORT must be junit or json: %s", format)
+	}
+	if dir == "" {
+		dir = "."
+	}
+	return &fileReport{
+		err:    err,
+		format: format,
+		dir:    dir,
+		names:  map[string]*reportCase{},
+		roots:  map[string]bool{},
+	}
+}
+
+// enabled returns true if the report file is enabled.
+func (r *fileReport) enabled() bool {
+	return r.format != ""
+}
+
+// get returns the case of the test, the case is created if not exists. The
+// mutex must be locked.
+func (r *fileReport) get(name string) *reportCase {
+	c, ok := r.names[name]
+	if !ok {
+		c = r.start(name)
+	}
+	return c
+}
+
+// start creates the case of a new execution of the test, the cases of the
+// previous executions (-count) are kept in the report. The mutex must be
+// locked.
+func (r *fileReport) start(name string) *reportCase {
+	c := &reportCase{name: name, start: time.Now()}
+	r.names[name] = c
+	r.cases = append(r.cases, c)
+	return c
+}
+
+// root registers a top level test; the report file is written at the end of
+// this test.
+func (r *fileReport) root(t testing.TB) {
+	if r.err != nil {
+		t.Helper()
+		t.Fatalf("%s", r.err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pkg == "" {
+		r.pkg = callerPackage()
+	}
+	if r.roots[t.Name()] {
+		return
+	}
+	r.roots[t.Name()] = true
+	if c, ok := r.names[t.Name()]; !ok || c.done {
+		r.start(t.Name())
+	}
+	t.Cleanup(func() {
+		r.end(t)
+		r.mu.Lock()
+		delete(r.roots, t.Name())
+		r.mu.Unlock()
+		if err := r.write(); err != nil {
+			panic(err)
+		}
+	})
+}
+
+// wrap records the start and the end of the subtest executed by fn.
+func (r *fileReport) wrap(fn func(testing.TB)) func(testing.TB) {
+	return func(t testing.TB) {
+		r.mu.Lock()
+		r.start(t.Name())
+		r.mu.Unlock()
+		defer r.end(t)
+		fn(t)
+	}
+}
+
+// end records the status and the duration of the test.
+func (r *fileReport) end(t testing.TB) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.get(t.Name())
+	c.duration = time.Since(c.start)
+	c.failed = t.Failed()
+	c.skipped = t.Skipped()
+	c.done = true
+}
+
+// Report records the failure in the case of the test.
+func (r *fileReport) Report(t testing.TB, f Failure) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.get(t.Name())
+	c.failures = append(c.failures, f)
+}
+
+// write writes the report file.
+func (r *fileReport) write() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var (
+		data []byte
+		err  error
+	)
+	if r.format == "junit" {
+		data, err = r.junit()
+	} else {
+		data, err = r.json()
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return err
+	}
+	ext := map[string]string{"junit": ".xml", "json": ".json"}[r.format]
+	name := strings.ReplaceAll(r.pkg, "/", "_")
+	return ioutil.WriteFile(filepath.Join(r.dir, name+ext), data, 0644)
+}
+
+// callerPackage returns the import path of the package of the test, or the
+// name of the test binary if it is not found.
+func callerPackage() string {
+	fn := callerFrame().Function
+	if fn == "" {
+		pkg := strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+		return strings.TrimSuffix(pkg, ".test")
+	}
+	slash := strings.LastIndex(fn, "/")
+	if dot := strings.Index(fn[slash+1:], "."); dot >= 0 {
+		fn = fn[:slash+1+dot]
+	}
+	return strings.TrimSuffix(fn, "_test")
+}
+
+// failedChild returns true if a subtest of c failed.
+func (r *fileReport) failedChild(c *reportCase) bool {
+	for _, o := range r.cases {
+		if o.failed && strings.HasPrefix(o.name, c.name+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	ClassName string         `xml:"classname,attr"`
+	Name      string         `xml:"name,attr"`
+	Time      string         `xml:"time,attr"`
+	Skipped   *struct{}      `xml:"skipped"`
+	Failures  []junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Type     string `xml:"type,attr"`
+	Message  string `xml:"message,attr"`
+	Expected string `xml:"expected,attr,omitempty"`
+	Got      string `xml:"got,attr,omitempty"`
+	File     string `xml:"file,attr,omitempty"`
+	Line     int    `xml:"line,attr,omitempty"`
+	Text     string `xml:",chardata"`
+}
+
+// junit returns the report in the JUnit XML format. The mutex must be locked.
+func (r *fileReport) junit() ([]byte, error) {
+	suite := junitSuite{Name: r.pkg}
+	var total time.Duration
+	for _, c := range r.cases {
+		jc := junitCase{ClassName: r.pkg, Name: c.name, Time: seconds(c.duration)}
+		if c.skipped {
+			jc.Skipped = &struct{}{}
+			suite.Skipped++
+		}
+		for _, f := range c.failures {
+			exp, got := reportValues(f)
+			jc.Failures = append(jc.Failures, junitFailure{
+				Type:     f.Assertion,
+				Message:  f.Message,
+				Expected: exp,
+				Got:      got,
+				File:     f.Frame.File,
+				Line:     f.Frame.Line,
+				Text:     f.Text,
+			})
+		}
+		if c.failed && len(c.failures) == 0 && !r.failedChild(c) {
+			jc.Failures = append(jc.Failures, junitFailure{Message: "test failed"})
+		}
+		if len(jc.Failures) > 0 {
+			suite.Failures++
+		}
+		if !strings.Contains(c.name, "/") {
+			total += c.duration
+		}
+		suite.Cases = append(suite.Cases, jc)
+	}
+	suite.Tests = len(suite.Cases)
+	suite.Time = seconds(total)
+	data, err := xml.MarshalIndent(junitSuites{Suites: []junitSuite{suite}}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+type jsonReport struct {
+	Package string     `json:"package"`
+	Tests   []jsonCase `json:"tests"`
+}
+
+type jsonCase struct {
+	Name     string        `json:"name"`
+	Time     float64       `json:"time"`
+	Failed   bool          `json:"failed"`
+	Skipped  bool          `json:"skipped"`
+	Failures []jsonFailure `json:"failures,omitempty"`
+}
+
+type jsonFailure struct {
+	Assertion string `json:"assertion"`
+	Expected  string `json:"expected,omitempty"`
+	Got       string `json:"got,omitempty"`
+	Message   string `json:"message,omitempty"`
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Function  string `json:"function"`
+	Text      string `json:"text"`
+}
+
+// json returns the report in the JSON format. The mutex must be locked.
+func (r *fileReport) json() ([]byte, error) {
+	rep := jsonReport{Package: r.pkg, Tests: []jsonCase{}}
+	for _, c := range r.cases {
+		jc := jsonCase{
+			Name:    c.name,
+			Time:    c.duration.Seconds(),
+			Failed:  c.failed,
+			Skipped: c.skipped,
+		}
+		for _, f := range c.failures {
+			exp, got := reportValues(f)
+			jc.Failures = append(jc.Failures, jsonFailure{
+				Assertion: f.Assertion,
+				Expected:  exp,
+				Got:       got,
+				Message:   f.Message,
+				File:      f.Frame.File,
+				Line:      f.Frame.Line,
+				Function:  f.Frame.Function,
+				Text:      f.Text,
+			})
+		}
+		rep.Tests = append(rep.Tests, jc)
+	}
+	data, err := json.MarshalIndent(rep, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// reportValues formats the expected and the got values of the failure.
+func reportValues(f Failure) (string, string) {
+	format := func(v interface{}) string {
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprintf("%+v", v)
+	}
+	return format(f.Exp), format(f.Got)
+}
+
+// seconds formats a duration in seconds.
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
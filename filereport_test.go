@@ -0,0 +1,101 @@
+package assert
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testFileReport(t *testing.T, format string) []byte {
+	dir, err := ioutil.TempDir("", "go-assert-")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+
+	r := newFileReport(format, dir)
+	r.pkg = "pkg"
+	t.Run("root", func(t *testing.T) {
+		r.root(t)
+		run(t, "sub", r.wrap(func(t testing.TB) {
+			r.Report(t, Failure{Assertion: "Equal", Exp: 1, Got: 2, Message: "msg", Text: "msg\nExp: 1\nGot: 2\n"})
+		}))
+	})
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "pkg."+map[string]string{"junit": "xml", "json": "json"}[format]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestFileReportJUnit(t *testing.T) {
+	data := string(testFileReport(t, "junit"))
+	for _, s := range []string{
+		`<testsuite name="pkg" tests="2" failures="1" skipped="0"`,
+		`<testcase classname="pkg" name="TestFileReportJUnit/root"`,
+		`<testcase classname="pkg" name="TestFileReportJUnit/root/sub"`,
+		`<failure type="Equal" message="msg" expected="1" got="2">`,
+	} {
+		if !strings.Contains(data, s) {
+			t.Errorf("%s not found in:\n%s", s, data)
+		}
+	}
+}
+
+func TestFileReportJSON(t *testing.T) {
+	var rep jsonReport
+	if err := json.Unmarshal(testFileReport(t, "json"), &rep); err != nil {
+		t.Fatal(err)
+	}
+	if rep.Package != "pkg" || len(rep.Tests) != 2 {
+		t.Fatalf("invalid report: %#v", rep)
+	}
+	sub := rep.Tests[1]
+	if sub.Name != "TestFileReportJSON/root/sub" || len(sub.Failures) != 1 {
+		t.Fatalf("invalid subtest: %#v", sub)
+	}
+	f := sub.Failures[0]
+	if f.Assertion != "Equal" || f.Expected != "1" || f.Got != "2" || f.Message != "msg" {
+		t.Errorf("invalid failure: %#v", f)
+	}
+}
+
+func TestFileReportCount(t *testing.T) {
+	dir := t.TempDir()
+	r := newFileReport("json", dir)
+	r.pkg = "pkg"
+	for i := 0; i < 2; i++ {
+		tb := &runTB{fakeTB: &fakeTB{TB: t}, name: "TestCount"}
+		r.root(tb)
+		r.root(tb)
+		r.Report(tb, Failure{Assertion: "True", Text: "run"})
+		tb.end()
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "pkg.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rep jsonReport
+	if err := json.Unmarshal(data, &rep); err != nil {
+		t.Fatal(err)
+	}
+	if len(rep.Tests) != 2 || len(rep.Tests[0].Failures) != 1 || len(rep.Tests[1].Failures) != 1 {
+		t.Errorf("invalid report: %#v", rep)
+	}
+}
+
+func TestFileReportPackage(t *testing.T) {
+	if got := callerPackage(); got != "github.com/gribouille/go-assert" {
+		t.Errorf("invalid package: %s", got)
+	}
+	failures, ok := New(t).record(func(a *Assert) {
+		newFileReport("xml", "").root(a.t)
+	})
+	if ok || len(failures) != 1 || failures[0].Text != "GO_ASSERT_REPORT must be junit or json: xml\n" {
+		t.Errorf("invalid format not reported: %v", failures)
+	}
+}
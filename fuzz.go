@@ -0,0 +1,44 @@
+package assert
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+)
+
+// seedType returns the type of the argument of the fuzz target fn that
+// receives the content of the seed files.
+func seedType(fn interface{}) reflect.Type {
+	typ := reflect.TypeOf(fn)
+	if typ == nil || typ.Kind() != reflect.Func || typ.NumIn() != 2 {
+		panic(fmt.Sprintf("seed files require a func(*Assert, []byte) or a func(*Assert, string): %T", fn))
+	}
+	switch in := typ.In(1); in {
+	case reflect.TypeOf([]byte(nil)), reflect.TypeOf(""):
+		return in
+	}
+	panic(fmt.Sprintf("seed files require a func(*Assert, []byte) or a func(*Assert, string): %T", fn))
+}
+
+// readSeeds returns the contents of the file src or of the files of the
+// folder src, walked like the sources of Cp.
+func readSeeds(src string) [][]byte {
+	info, err := os.Stat(src)
+	if err != nil {
+		panic(err)
+	}
+	var res [][]byte
+	err = walk(src, info, func(path string, info os.FileInfo) error {
+		if info.IsDir() {
+			return nil
+		}
+		data, err := ioutil.ReadFile(path)
+		res = append(res, data)
+		return err
+	})
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
@@ -0,0 +1,55 @@
+package assert
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func FuzzSeeds(f *testing.F) {
+	dir := f.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0755); err != nil {
+		f.Fatal(err)
+	}
+	for name, data := range map[string]string{"a.txt": "a", "sub/b.txt": "bb"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			f.Fatal(err)
+		}
+	}
+	var got []string
+	New(f).Fuzz(func(a *Assert, s string) {
+		got = append(got, s)
+		a.SetFatal(true).Equal(s, s)
+	}, dir)
+	if flag.Lookup("test.fuzz").Value.String() != "" {
+		return // the inputs are executed by the workers
+	}
+	sort.Strings(got)
+	if len(got) != 2 || got[0] != "a" || got[1] != "bb" {
+		f.Errorf("seeds not executed: %q", got)
+	}
+}
+
+func TestReadSeeds(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "seed")
+	if err := ioutil.WriteFile(file, []byte("seed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := readSeeds(file); len(got) != 1 || string(got[0]) != "seed" {
+		t.Errorf("invalid seeds: %q", got)
+	}
+	if typ := seedType(func(*Assert, []byte) {}); typ != reflect.TypeOf([]byte(nil)) {
+		t.Errorf("invalid type: %s", typ)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("seedType did not panic")
+		}
+	}()
+	seedType(func(*Assert, int) {})
+}
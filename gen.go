@@ -0,0 +1,557 @@
+package assert
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+)
+
+// Gen generates the random values of a Property.
+type Gen[T any] interface {
+	// Generate returns a random value. The size grows with the number of
+	// executions of the property, it bounds the length of the collections.
+	Generate(r *rand.Rand, size int) T
+	// Shrink returns the values simpler than v, the simplest first.
+	Shrink(v T) []T
+}
+
+// NewGen creates a generator from the functions generate and shrink. shrink
+// can be nil if the values cannot be shrunk.
+func NewGen[T any](generate func(r *rand.Rand, size int) T, shrink func(T) []T) Gen[T] {
+	if shrink == nil {
+		shrink = func(T) []T { return nil }
+	}
+	return gen[T]{generate, shrink}
+}
+
+type gen[T any] struct {
+	generate func(*rand.Rand, int) T
+	shrink   func(T) []T
+}
+
+func (g gen[T]) Generate(r *rand.Rand, size int) T { return g.generate(r, size) }
+func (g gen[T]) Shrink(v T) []T                    { return g.shrink(v) }
+
+// Integer is the constraint of the integer types.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is the constraint of the floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Alphabets of the string generators.
+const (
+	Lower    = "abcdefghijklmnopqrstuvwxyz"
+	Upper    = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Digits   = "0123456789"
+	Alpha    = Lower + Upper
+	AlphaNum = Alpha + Digits
+	ASCII    = " !\"#$%&'()*+,-./" + Digits + ":;<=>?@" + Upper + "[\\]^_`" + Lower + "{|}~"
+)
+
+// Ints generates the integers between min and max (included). The integers
+// shrink towards 0, or the bound the closest to 0.
+func Ints[I Integer](min, max I) Gen[I] {
+	if min > max {
+		panic(fmt.Sprintf("invalid range [%v, %v]", min, max))
+	}
+	target := I(0)
+	if min > 0 {
+		target = min
+	} else if max < 0 {
+		target = max
+	}
+	return gen[I]{
+		generate: func(r *rand.Rand, _ int) I {
+			// the bounds and the target are more likely to find a bug
+			switch r.Intn(10) {
+			case 0:
+				return min
+			case 1:
+				return max
+			case 2:
+				return target
+			}
+			span := uint64(max) - uint64(min)
+			if span == math.MaxUint64 {
+				return I(r.Uint64())
+			}
+			return I(uint64(min) + r.Uint64()%(span+1))
+		},
+		shrink: func(v I) []I {
+			if v == target {
+				return nil
+			}
+			res := []I{target}
+			for d := v/2 - target/2; d != 0; d /= 2 {
+				if c := v - d; c != target {
+					res = append(res, c)
+				}
+			}
+			if v > target {
+				res = append(res, v-1)
+			} else {
+				res = append(res, v+1)
+			}
+			return res
+		},
+	}
+}
+
+// Floats generates the floating-point numbers between min and max. The
+// numbers shrink towards 0, or the bound the closest to 0.
+func Floats[F Float](min, max F) Gen[F] {
+	if min > max {
+		panic(fmt.Sprintf("invalid range [%v, %v]", min, max))
+	}
+	target := F(0)
+	if min > 0 {
+		target = min
+	} else if max < 0 {
+		target = max
+	}
+	return gen[F]{
+		generate: func(r *rand.Rand, _ int) F {
+			switch r.Intn(10) {
+			case 0:
+				return min
+			case 1:
+				return max
+			case 2:
+				return target
+			}
+			return min + F(r.Float64())*(max-min)
+		},
+		shrink: func(v F) []F {
+			if v == target {
+				return nil
+			}
+			res := []F{target}
+			if t := F(math.Trunc(float64(v))); t != v && t != target && t >= min && t <= max {
+				res = append(res, t)
+			}
+			if math.Abs(float64(v-target)) > 1e-6 {
+				res = append(res, v-(v-target)/2)
+			}
+			return res
+		},
+	}
+}
+
+// Bools generates the booleans. true shrinks to false.
+func Bools() Gen[bool] {
+	return gen[bool]{
+		generate: func(r *rand.Rand, _ int) bool { return r.Intn(2) == 1 },
+		shrink: func(v bool) []bool {
+			if v {
+				return []bool{false}
+			}
+			return nil
+		},
+	}
+}
+
+// Strings generates the strings of maximum maxLen characters of the
+// alphabet. The strings shrink to the shorter strings and to the first
+// character of the alphabet.
+func Strings(alphabet string, maxLen int) Gen[string] {
+	chars := []rune(alphabet)
+	if len(chars) == 0 {
+		panic("empty alphabet")
+	}
+	shrinkRune := func(c rune) []rune {
+		if c == chars[0] {
+			return nil
+		}
+		return []rune{chars[0]}
+	}
+	return gen[string]{
+		generate: func(r *rand.Rand, size int) string {
+			s := make([]rune, r.Intn(bound(size, maxLen)+1))
+			for i := range s {
+				s[i] = chars[r.Intn(len(chars))]
+			}
+			return string(s)
+		},
+		shrink: func(v string) []string {
+			var res []string
+			for _, s := range shrinkSlice([]rune(v), shrinkRune) {
+				res = append(res, string(s))
+			}
+			return res
+		},
+	}
+}
+
+// SliceOf generates the slices of maximum maxLen elements generated by g.
+// The slices shrink by removing and shrinking the elements.
+func SliceOf[T any](g Gen[T], maxLen int) Gen[[]T] {
+	return gen[[]T]{
+		generate: func(r *rand.Rand, size int) []T {
+			s := make([]T, r.Intn(bound(size, maxLen)+1))
+			for i := range s {
+				s[i] = g.Generate(r, size)
+			}
+			return s
+		},
+		shrink: func(v []T) [][]T {
+			return shrinkSlice(v, g.Shrink)
+		},
+	}
+}
+
+// MapOf generates the maps of maximum maxLen entries with the keys and the
+// values generated by k and v. The maps shrink by removing the entries and
+// shrinking the values.
+func MapOf[K comparable, V any](k Gen[K], v Gen[V], maxLen int) Gen[map[K]V] {
+	return gen[map[K]V]{
+		generate: func(r *rand.Rand, size int) map[K]V {
+			n := r.Intn(bound(size, maxLen) + 1)
+			m := make(map[K]V, n)
+			for i := 0; i < n; i++ {
+				m[k.Generate(r, size)] = v.Generate(r, size)
+			}
+			return m
+		},
+		shrink: func(m map[K]V) []map[K]V {
+			keys := make([]reflect.Value, 0, len(m))
+			for key := range m {
+				keys = append(keys, reflect.ValueOf(key))
+			}
+			sortValues(keys)
+			var res []map[K]V
+			if len(m) > 0 {
+				res = append(res, map[K]V{})
+			}
+			for _, key := range keys {
+				c := copyMap(m)
+				delete(c, key.Interface().(K))
+				res = append(res, c)
+			}
+			for _, key := range keys {
+				key := key.Interface().(K)
+				for _, s := range v.Shrink(m[key]) {
+					c := copyMap(m)
+					c[key] = s
+					res = append(res, c)
+				}
+			}
+			return res
+		},
+	}
+}
+
+// StructOf generates the structs of type T. The generators of the exported
+// fields are derived from their types by reflection, the unexported fields
+// are zero:
+//   - integers: Ints with the bounds of the type
+//   - floats: Floats(-1e6, 1e6)
+//   - strings: Strings(ASCII, 32)
+//   - slices, arrays, maps, pointers and nested structs
+//
+// StructOf panics if a field cannot be generated (channel, function,
+// interface).
+func StructOf[T any]() Gen[T] {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("StructOf requires a struct type: %s", typ))
+	}
+	g := derive(typ)
+	return gen[T]{
+		generate: func(r *rand.Rand, size int) T {
+			return g.generate(r, size).Interface().(T)
+		},
+		shrink: func(v T) []T {
+			var res []T
+			for _, s := range g.shrink(reflect.ValueOf(v)) {
+				res = append(res, s.Interface().(T))
+			}
+			return res
+		},
+	}
+}
+
+// maxDerivedLen is the maximum length of the collections generated by
+// StructOf. The size is shared between the elements of the collections and
+// halved by the pointers to stop the recursive types.
+const maxDerivedLen = 16
+
+// valueGen is a generator of the values of a type known at run time.
+type valueGen struct {
+	generate func(*rand.Rand, int) reflect.Value
+	shrink   func(reflect.Value) []reflect.Value
+}
+
+// derive returns the generator of the values of type typ.
+func derive(typ reflect.Type) valueGen {
+	return deriveType(typ, map[reflect.Type]*valueGen{})
+}
+
+// deriveType returns the generator of the values of type typ. seen contains
+// the generators of the types being derived for the recursive types.
+func deriveType(typ reflect.Type, seen map[reflect.Type]*valueGen) valueGen {
+	if g, ok := seen[typ]; ok {
+		return valueGen{
+			generate: func(r *rand.Rand, size int) reflect.Value { return g.generate(r, size) },
+			shrink:   func(v reflect.Value) []reflect.Value { return g.shrink(v) },
+		}
+	}
+	g := &valueGen{}
+	seen[typ] = g
+	*g = deriveKind(typ, seen)
+	return *g
+}
+
+func deriveKind(typ reflect.Type, seen map[reflect.Type]*valueGen) valueGen {
+	switch typ.Kind() {
+	case reflect.Bool:
+		return valueGenOf(Bools(), typ)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := typ.Bits()
+		return valueGenOf(Ints[int64](-1<<(bits-1), 1<<(bits-1)-1), typ)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return valueGenOf(Ints[uint64](0, math.MaxUint64>>(64-typ.Bits())), typ)
+	case reflect.Float32, reflect.Float64:
+		return valueGenOf(Floats[float64](-1e6, 1e6), typ)
+	case reflect.String:
+		return valueGenOf(Strings(ASCII, 32), typ)
+	case reflect.Slice:
+		return deriveSlice(typ, seen)
+	case reflect.Array:
+		return deriveArray(typ, seen)
+	case reflect.Map:
+		return deriveMap(typ, seen)
+	case reflect.Struct:
+		return deriveStruct(typ, seen)
+	case reflect.Ptr:
+		return derivePtr(typ, seen)
+	}
+	panic(fmt.Sprintf("cannot generate values of type %s", typ))
+}
+
+// valueGenOf converts the generator g into a generator of the values of type
+// typ, convertible from and to T.
+func valueGenOf[T any](g Gen[T], typ reflect.Type) valueGen {
+	gt := reflect.TypeOf((*T)(nil)).Elem()
+	return valueGen{
+		generate: func(r *rand.Rand, size int) reflect.Value {
+			return reflect.ValueOf(g.Generate(r, size)).Convert(typ)
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			var res []reflect.Value
+			for _, s := range g.Shrink(v.Convert(gt).Interface().(T)) {
+				res = append(res, reflect.ValueOf(s).Convert(typ))
+			}
+			return res
+		},
+	}
+}
+
+func deriveSlice(typ reflect.Type, seen map[reflect.Type]*valueGen) valueGen {
+	elem := deriveType(typ.Elem(), seen)
+	return valueGen{
+		generate: func(r *rand.Rand, size int) reflect.Value {
+			n := r.Intn(bound(size, maxDerivedLen) + 1)
+			v := reflect.MakeSlice(typ, n, n)
+			for i := 0; i < n; i++ {
+				v.Index(i).Set(elem.generate(r, size/(n+1)))
+			}
+			return v
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			var res []reflect.Value
+			for _, s := range shrinkSlice(elements(v), elem.shrink) {
+				c := reflect.MakeSlice(typ, len(s), len(s))
+				for i, e := range s {
+					c.Index(i).Set(e)
+				}
+				res = append(res, c)
+			}
+			return res
+		},
+	}
+}
+
+func deriveArray(typ reflect.Type, seen map[reflect.Type]*valueGen) valueGen {
+	elem := deriveType(typ.Elem(), seen)
+	return valueGen{
+		generate: func(r *rand.Rand, size int) reflect.Value {
+			v := reflect.New(typ).Elem()
+			for i := 0; i < v.Len(); i++ {
+				v.Index(i).Set(elem.generate(r, size))
+			}
+			return v
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			var res []reflect.Value
+			for i := 0; i < v.Len(); i++ {
+				for _, s := range elem.shrink(v.Index(i)) {
+					c := reflect.New(typ).Elem()
+					c.Set(v)
+					c.Index(i).Set(s)
+					res = append(res, c)
+				}
+			}
+			return res
+		},
+	}
+}
+
+func deriveMap(typ reflect.Type, seen map[reflect.Type]*valueGen) valueGen {
+	key, elem := deriveType(typ.Key(), seen), deriveType(typ.Elem(), seen)
+	return valueGen{
+		generate: func(r *rand.Rand, size int) reflect.Value {
+			n := r.Intn(bound(size, maxDerivedLen) + 1)
+			v := reflect.MakeMapWithSize(typ, n)
+			for i := 0; i < n; i++ {
+				v.SetMapIndex(key.generate(r, size), elem.generate(r, size/(n+1)))
+			}
+			return v
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			keys := v.MapKeys()
+			sortValues(keys)
+			clone := func(skip int) reflect.Value {
+				c := reflect.MakeMapWithSize(typ, v.Len())
+				for i, k := range keys {
+					if i != skip {
+						c.SetMapIndex(k, v.MapIndex(k))
+					}
+				}
+				return c
+			}
+			var res []reflect.Value
+			if v.Len() > 0 {
+				res = append(res, reflect.MakeMap(typ))
+			}
+			for i := range keys {
+				res = append(res, clone(i))
+			}
+			for _, k := range keys {
+				for _, s := range elem.shrink(v.MapIndex(k)) {
+					c := clone(-1)
+					c.SetMapIndex(k, s)
+					res = append(res, c)
+				}
+			}
+			return res
+		},
+	}
+}
+
+func deriveStruct(typ reflect.Type, seen map[reflect.Type]*valueGen) valueGen {
+	fields := make([]*valueGen, typ.NumField())
+	for i := range fields {
+		if f := typ.Field(i); f.PkgPath == "" {
+			g := deriveType(f.Type, seen)
+			fields[i] = &g
+		}
+	}
+	return valueGen{
+		generate: func(r *rand.Rand, size int) reflect.Value {
+			v := reflect.New(typ).Elem()
+			for i, g := range fields {
+				if g != nil {
+					v.Field(i).Set(g.generate(r, size))
+				}
+			}
+			return v
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			var res []reflect.Value
+			for i, g := range fields {
+				if g == nil {
+					continue
+				}
+				for _, s := range g.shrink(v.Field(i)) {
+					c := reflect.New(typ).Elem()
+					c.Set(v)
+					c.Field(i).Set(s)
+					res = append(res, c)
+				}
+			}
+			return res
+		},
+	}
+}
+
+func derivePtr(typ reflect.Type, seen map[reflect.Type]*valueGen) valueGen {
+	elem := deriveType(typ.Elem(), seen)
+	return valueGen{
+		generate: func(r *rand.Rand, size int) reflect.Value {
+			if size == 0 || r.Intn(5) == 0 {
+				return reflect.Zero(typ)
+			}
+			v := reflect.New(typ.Elem())
+			v.Elem().Set(elem.generate(r, size/2))
+			return v
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			if v.IsNil() {
+				return nil
+			}
+			res := []reflect.Value{reflect.Zero(typ)}
+			for _, s := range elem.shrink(v.Elem()) {
+				c := reflect.New(typ.Elem())
+				c.Elem().Set(s)
+				res = append(res, c)
+			}
+			return res
+		},
+	}
+}
+
+// shrinkSlice returns the slices simpler than s: the empty slice, the halves
+// of s, s without one element, then s with one shrunk element.
+func shrinkSlice[T any](s []T, shrink func(T) []T) [][]T {
+	var res [][]T
+	if len(s) == 0 {
+		return nil
+	}
+	res = append(res, []T{})
+	if len(s) > 2 {
+		res = append(res, append([]T{}, s[:len(s)/2]...), append([]T{}, s[len(s)/2:]...))
+	}
+	for i := range s {
+		c := append([]T{}, s[:i]...)
+		res = append(res, append(c, s[i+1:]...))
+	}
+	for i, e := range s {
+		for _, v := range shrink(e) {
+			c := append([]T{}, s...)
+			c[i] = v
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// elements returns the elements of the slice v.
+func elements(v reflect.Value) []reflect.Value {
+	res := make([]reflect.Value, v.Len())
+	for i := range res {
+		res[i] = v.Index(i)
+	}
+	return res
+}
+
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	c := make(map[K]V, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// bound returns the maximum length of a collection for the size.
+func bound(size, maxLen int) int {
+	if size < maxLen {
+		return size
+	}
+	return maxLen
+}
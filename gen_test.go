@@ -0,0 +1,114 @@
+package assert
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestInts(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := Ints[int8](-5, 10)
+	for i := 0; i < 100; i++ {
+		if v := g.Generate(r, i); v < -5 || v > 10 {
+			t.Errorf("out of range: %d", v)
+		}
+	}
+	if s := g.Shrink(8); len(s) == 0 || s[0] != 0 {
+		t.Errorf("invalid shrink: %v", s)
+	}
+	if s := Ints[uint](3, 9).Shrink(3); len(s) != 0 {
+		t.Errorf("invalid shrink: %v", s)
+	}
+	if v := Ints[uint64](0, 1<<64-1).Generate(r, 0); v < 0 {
+		t.Errorf("invalid value: %d", v)
+	}
+}
+
+func TestFloats(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := Floats(1.5, 2.5)
+	for i := 0; i < 100; i++ {
+		if v := g.Generate(r, i); v < 1.5 || v > 2.5 {
+			t.Errorf("out of range: %f", v)
+		}
+	}
+	if s := g.Shrink(2.25); len(s) == 0 || s[0] != 1.5 {
+		t.Errorf("invalid shrink: %v", s)
+	}
+}
+
+func TestStrings(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := Strings("ab", 5)
+	if v := g.Generate(r, 0); v != "" {
+		t.Errorf("got: %q, exp: empty string", v)
+	}
+	for i := 0; i < 100; i++ {
+		v := g.Generate(r, i)
+		if len(v) > 5 || len(v) != len([]rune(v)) {
+			t.Errorf("invalid string: %q", v)
+		}
+	}
+	s := g.Shrink("ba")
+	exp := []string{"", "a", "b", "aa"}
+	if len(s) != len(exp) {
+		t.Fatalf("got: %q, exp: %q", s, exp)
+	}
+	for i := range s {
+		if s[i] != exp[i] {
+			t.Errorf("got: %q, exp: %q", s, exp)
+		}
+	}
+}
+
+func TestMapOf(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := MapOf(Strings(Lower, 3), Bools(), 4)
+	for i := 0; i < 100; i++ {
+		if v := g.Generate(r, i); len(v) > 4 {
+			t.Errorf("too many entries: %v", v)
+		}
+	}
+	s := g.Shrink(map[string]bool{"a": true, "b": false})
+	if len(s) != 4 || len(s[0]) != 0 || s[1]["a"] || !s[2]["a"] || s[3]["a"] {
+		t.Errorf("invalid shrink: %v", s)
+	}
+}
+
+type genUser struct {
+	Name    string
+	Age     uint8
+	Score   float32
+	Tags    []string
+	Friends map[string]int
+	Parent  *genUser
+	Kids    []genUser
+	Pos     [2]int16
+	secret  int
+}
+
+func TestStructOf(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := StructOf[genUser]()
+	var v genUser
+	for i := 0; i < 50; i++ {
+		v = g.Generate(r, i)
+		if len(v.Tags) > maxDerivedLen || v.secret != 0 {
+			t.Errorf("invalid value: %#v", v)
+		}
+	}
+	for _, s := range g.Shrink(v) {
+		if s.secret != 0 {
+			t.Errorf("invalid shrink: %#v", s)
+		}
+	}
+	if s := g.Shrink(genUser{}); len(s) != 0 {
+		t.Errorf("zero value shrunk: %v", s)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("StructOf did not panic")
+		}
+	}()
+	StructOf[struct{ C chan int }]()
+}
@@ -0,0 +1,238 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Generic assertions.
+//
+// These functions are similar to the methods of Assert but the types of the
+// expected and the got values are checked by the compiler:
+// 	T.EqualOf(a, 3, int64(3)) // does not compile
+//
+// As the Go methods cannot have type parameters, they take the Assert as first
+// argument and return it to chain the assertions:
+// 	T.EqualOf(a, "a", "a").True(true)
+
+// Ordered is the constraint of the types that support the operators < <= >= >.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// EqualOf is the generic version of Equal.
+func EqualOf[T comparable](a *Assert, exp, got T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if exp != got {
+			a.errorMessage("EqualOf", exp, got, "%s", a.equalMessage(exp, got))(msg...)
+		}
+	})
+}
+
+// NotEqualOf is the generic version of NotEqual.
+func NotEqualOf[T comparable](a *Assert, exp, got T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if exp == got {
+			a.errorMessage("NotEqualOf", exp, got, "Equal: %+v\n", exp)(msg...)
+		}
+	})
+}
+
+// EqualDeepOf is the generic version of EqualDeep.
+func EqualDeepOf[T any](a *Assert, exp, got T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if !reflect.DeepEqual(exp, got) {
+			a.errorMessage("EqualDeepOf", exp, got, "%s", deepMessage(exp, got))(msg...)
+		}
+	})
+}
+
+// EqualSliceOf compares two slices of any comparable type.
+//
+// If the assertion fails then a message shows the first differences:
+//
+//	Error:
+//	  Exp: [1 2 3 4]
+//	  Got: [1 2 4 4]
+//	   ┗━━━━━┛
+func EqualSliceOf[T comparable](a *Assert, exp, got []T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if len(exp) != len(got) {
+			a.errorMessage("EqualSliceOf", exp, got, "Expected size: %d, got size: %d", len(exp), len(got))(msg...)
+			return
+		}
+		if dash := sliceMismatch(exp, got, func(x, y T) bool { return x == y }); dash > 0 {
+			d := strings.Repeat("━", dash)
+			a.errorMessage("EqualSliceOf", exp, got, "Exp: %+v\nGot: %+v\n ┗%s┛\n", exp, got, d)(msg...)
+		}
+	})
+}
+
+// EqualMapOf is the generic version of EqualMap.
+func EqualMapOf[K comparable, V any](a *Assert, exp, got map[K]V, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if diff := mapDiff(reflect.ValueOf(exp), reflect.ValueOf(got), true); diff != "" {
+			a.errorMessage("EqualMapOf", exp, got, "%s", diff)(msg...)
+		}
+	})
+}
+
+// LessOf is the generic version of Less.
+func LessOf[T Ordered](a *Assert, x, y T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		c, err := compare(x, y)
+		a.relation("LessOf", x, "<", y, c, err, msg)
+	})
+}
+
+// LessOrEqualOf is the generic version of LessOrEqual.
+func LessOrEqualOf[T Ordered](a *Assert, x, y T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		c, err := compare(x, y)
+		a.relation("LessOrEqualOf", x, "<=", y, c, err, msg)
+	})
+}
+
+// GreaterOf is the generic version of Greater.
+func GreaterOf[T Ordered](a *Assert, x, y T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		c, err := compare(x, y)
+		a.relation("GreaterOf", x, ">", y, c, err, msg)
+	})
+}
+
+// GreaterOrEqualOf is the generic version of GreaterOrEqual.
+func GreaterOrEqualOf[T Ordered](a *Assert, x, y T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		c, err := compare(x, y)
+		a.relation("GreaterOrEqualOf", x, ">=", y, c, err, msg)
+	})
+}
+
+// sliceMismatch returns the length of the marker that underlines the values
+// until the first difference between the slices with the same size, or 0 if
+// the slices are equal.
+func sliceMismatch[T any](exp, got []T, eq func(T, T) bool) int {
+	dash := 4
+	for i := range exp {
+		dash += len(fmt.Sprintf("%+v", exp[i]))
+		if !eq(exp[i], got[i]) {
+			return dash
+		}
+	}
+	return 0
+}
+
+// InRangeOf is the generic version of InRange.
+func InRangeOf[T Ordered](a *Assert, v, min, max T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		cmin, err := compare(v, min)
+		cmax := 0
+		if err == nil {
+			cmax, err = compare(v, max)
+		}
+		a.inRange("InRangeOf", v, min, max, cmin, cmax, err, msg)
+	})
+}
+
+// IsSortedOf is the generic version of IsSorted.
+func IsSortedOf[T Ordered](a *Assert, s []T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		checkSorted(a, "IsSortedOf", s, s, compare[T], msg)
+	})
+}
+
+// IsSortedByOf asserts that the elements of s are sorted by the function
+// less (see IsSorted).
+func IsSortedByOf[T any](a *Assert, s []T, less func(x, y T) bool, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if i := unsortedIndex(s, less); i > 0 {
+			a.errorMessage("IsSortedByOf", nil, s, "%s", unsortedMessage(s, i, "sorted", fmt.Sprintf("%+v before %+v", s[i], s[i-1])))(msg...)
+		}
+	})
+}
+
+// MonotonicOf is the generic version of Monotonic.
+func MonotonicOf[T Ordered](a *Assert, s []T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		checkMonotonic(a, "MonotonicOf", s, s, compare[T], msg)
+	})
+}
+
+// ContainsOf is the generic version of Contains for the slices.
+func ContainsOf[T comparable](a *Assert, s []T, elem T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if indexOf(s, elem) < 0 {
+			a.errorMessage("ContainsOf", elem, s, "Container: %+v\nMissing: %+v\n", s, elem)(msg...)
+		}
+	})
+}
+
+// NotContainsOf is the generic version of NotContains for the slices.
+func NotContainsOf[T comparable](a *Assert, s []T, elem T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if i := indexOf(s, elem); i >= 0 {
+			a.errorMessage("NotContainsOf", elem, s, "%sContains: %+v\n", markedList("Container", s, []int{i}), elem)(msg...)
+		}
+	})
+}
+
+// ElementsMatchOf is the generic version of ElementsMatch.
+func ElementsMatchOf[T comparable](a *Assert, exp, got []T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		missing, extra := matchElements(exp, got, func(x, y T) bool { return x == y })
+		if len(missing) > 0 || len(extra) > 0 {
+			a.errorMessage("ElementsMatchOf", exp, got, "%s", elementsMessage(exp, got, missing, extra))(msg...)
+		}
+	})
+}
+
+// SubsetOf is the generic version of Subset for the slices.
+func SubsetOf[T comparable](a *Assert, list, subset []T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if missing := missingElements(list, subset, func(x, y T) bool { return x == y }); len(missing) > 0 {
+			a.errorMessage("SubsetOf", subset, list, "%s", subsetMessage(list, subset, missing))(msg...)
+		}
+	})
+}
+
+// LenOf is the generic version of Len for the slices.
+func LenOf[T any](a *Assert, s []T, n int, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if len(s) != n {
+			a.errorMessage("LenOf", n, len(s), "Exp length: %d\nGot length: %d\nValue: %+v\n", n, len(s), s)(msg...)
+		}
+	})
+}
+
+// EmptyOf is the generic version of Empty for the slices.
+func EmptyOf[T any](a *Assert, s []T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if len(s) > 0 {
+			a.errorMessage("EmptyOf", nil, s, "Exp: empty\nGot: %+v\n", s)(msg...)
+		}
+	})
+}
+
+// NotEmptyOf is the generic version of NotEmpty for the slices.
+func NotEmptyOf[T any](a *Assert, s []T, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if len(s) == 0 {
+			a.errorMessage("NotEmptyOf", nil, s, "Exp: not empty\nGot: %+v\n", s)(msg...)
+		}
+	})
+}
+
+// indexOf returns the index of the first element of s equal to elem, or -1.
+func indexOf[T comparable](s []T, elem T) int {
+	for i, e := range s {
+		if e == elem {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,112 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEqualOf(t *testing.T) {
+	isAssert(t, EqualOf(New(t), int64(3), 3, "format str"))
+}
+
+func TestNotEqualOf(t *testing.T) {
+	isAssert(t, NotEqualOf(New(t), "a", "b"))
+}
+
+func TestEqualDeepOf(t *testing.T) {
+	isAssert(t, EqualDeepOf(New(t), []int{1, 2}, []int{1, 2}))
+}
+
+func TestEqualSliceOf(t *testing.T) {
+	isAssert(t, EqualSliceOf(New(t), []int{1, 2, 3}, []int{1, 2, 3}))
+}
+
+func TestEqualMapOf(t *testing.T) {
+	x, y := 1, 1
+	isAssert(t, EqualMapOf(New(t), map[string]int{"a": 1}, map[string]int{"a": 1}))
+	isAssert(t, EqualMapOf(New(t), map[string]*int{"a": &x}, map[string]*int{"a": &y}))
+
+	b, got := failures(t)
+	exp := map[string][]int{"c": {3}, "a": {1}, "b": {2}}
+	act := map[string][]int{"d": {4}, "b": {2, 0}, "a": {1}}
+	EqualMapOf(b, exp, act)
+	b.EqualMap(exp, act)
+	if len(*got) != 2 {
+		t.Fatalf("got: %d failures, exp: 2", len(*got))
+	}
+	if !strings.Contains((*got)[0].Text, "Missing keys:\n  \"c\": []int{3}\nUnexpected keys:\n  \"d\": []int{4}\nChanged keys:\n  \"b\"") {
+		t.Errorf("invalid message: %s", (*got)[0].Text)
+	}
+	if (*got)[0].Text != (*got)[1].Text {
+		t.Errorf("EqualMapOf:\n%s\nEqualMap:\n%s", (*got)[0].Text, (*got)[1].Text)
+	}
+}
+
+func TestOrderedOf(t *testing.T) {
+	a := New(t)
+	isAssert(t, LessOf(a, 1, 2))
+	isAssert(t, LessOrEqualOf(a, 2, 2))
+	isAssert(t, GreaterOf(a, "b", "a"))
+	isAssert(t, GreaterOrEqualOf(a, 2.5, 2.5))
+}
+
+func TestSliceMismatch(t *testing.T) {
+	eq := func(x, y string) bool { return x == y }
+	if d := sliceMismatch([]string{"aaa", "bbb"}, []string{"aaa", "bbb"}, eq); d != 0 {
+		t.Errorf("got: %d, exp: 0", d)
+	}
+	if d := sliceMismatch([]string{"aaa", "bbb"}, []string{"aaa", "bbc"}, eq); d != 10 {
+		t.Errorf("got: %d, exp: 10", d)
+	}
+}
+
+func TestCollectionsOf(t *testing.T) {
+	a := New(t)
+	isAssert(t, ContainsOf(a, []int{1, 2, 3}, 2))
+	isAssert(t, NotContainsOf(a, []string{"a", "b"}, "c"))
+	isAssert(t, ElementsMatchOf(a, []int{1, 2, 2}, []int{2, 1, 2}))
+	isAssert(t, SubsetOf(a, []int{1, 2, 3}, []int{3, 1}))
+	isAssert(t, LenOf(a, []string{"a"}, 1).True(true))
+	isAssert(t, EmptyOf(a, []int(nil)))
+	isAssert(t, NotEmptyOf(a, []int{0}))
+
+	b, got := failures(t)
+	ContainsOf(b, []int{1}, 2)
+	NotContainsOf(b, []int{1, 2}, 2)
+	ElementsMatchOf(b, []int{1, 2, 2}, []int{2, 1, 1})
+	SubsetOf(b, []int{1, 2}, []int{3, 1})
+	LenOf(b, []int{1}, 2)
+	EmptyOf(b, []int{1})
+	NotEmptyOf(b, []int{})
+	if len(*got) != 7 {
+		t.Fatalf("got: %d failures, exp: 7", len(*got))
+	}
+	exp := "Exp: [1 2 2]\n         ┗━┛\nGot: [2 1 1]\n         ┗━┛\nMissing: [2]\nExtra: [1]\n"
+	if !strings.HasSuffix((*got)[2].Text, exp) {
+		t.Errorf("got:\n%s\nexp:\n%s", (*got)[2].Text, exp)
+	}
+}
+
+func TestSortedOf(t *testing.T) {
+	a := New(t)
+	isAssert(t, InRangeOf(a, 3, 1, 5))
+	isAssert(t, IsSortedOf(a, []string{"a", "b", "b"}))
+	isAssert(t, IsSortedByOf(a, []int{3, 2, 1}, func(x, y int) bool { return x > y }))
+	isAssert(t, MonotonicOf(a, []float64{3, 2, 2, 1}))
+
+	b, got := failures(t)
+	InRangeOf(b, 9, 1, 5)
+	IsSortedOf(b, []int{2, 1})
+	IsSortedByOf(b, []int{1, 2}, func(x, y int) bool { return x > y })
+	MonotonicOf(b, []int{1, 2, 1})
+	LessOf(b, 2, 1)
+	if len(*got) != 5 {
+		t.Fatalf("got: %d failures, exp: 5", len(*got))
+	}
+	if !strings.HasSuffix((*got)[1].Text, "Got: [2 1]\n ┗━━━━━━━┛\nNot sorted at index 1: 1 < 2\n") {
+		t.Errorf("invalid message: %s", (*got)[1].Text)
+	}
+	if !strings.HasSuffix((*got)[4].Text, "Exp: 2 < 1\nGot: 2 >= 1\n") {
+		t.Errorf("invalid message: %s", (*got)[4].Text)
+	}
+}
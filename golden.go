@@ -0,0 +1,77 @@
+package assert
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// updateMode returns true if the golden files must be updated instead of
+// compared. The update mode is enabled with the environment variable
+// GO_ASSERT_UPDATE.
+func updateMode() bool {
+	return envBool("GO_ASSERT_UPDATE")
+}
+
+// goldens records the golden files updated by each top level test.
+var goldens = struct {
+	sync.Mutex
+	updated map[string][]string
+	roots   map[string]bool
+}{updated: map[string][]string{}, roots: map[string]bool{}}
+
+// rootName returns the name of the top level test of t.
+func rootName(t testing.TB) string {
+	return strings.SplitN(t.Name(), "/", 2)[0]
+}
+
+// goldenSummary logs the golden files updated by the top level test t at the
+// end of this test.
+func goldenSummary(t testing.TB) {
+	goldens.Lock()
+	defer goldens.Unlock()
+	root := rootName(t)
+	if goldens.roots[root] {
+		return
+	}
+	goldens.roots[root] = true
+	t.Cleanup(func() {
+		goldens.Lock()
+		defer goldens.Unlock()
+		files := goldens.updated[root]
+		// the next execution of the test (-count) starts a new summary
+		delete(goldens.roots, root)
+		delete(goldens.updated, root)
+		if len(files) == 0 {
+			return
+		}
+		t.Logf("Golden files updated by %s:\n  - %s", root, strings.Join(files, "\n  - "))
+	})
+}
+
+// updateGolden writes got in the golden file if the content changed. The
+// parent directories are created.
+func updateGolden(t testing.TB, got, filename string) {
+	old, err := ioutil.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		panic(err)
+	}
+	if err == nil && bytes.Equal(old, []byte(got)) {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(filename, []byte(got), 0644); err != nil {
+		panic(err)
+	}
+	goldens.Lock()
+	root := rootName(t)
+	goldens.updated[root] = append(goldens.updated[root], filename)
+	goldens.Unlock()
+	t.Logf("Golden file updated: %s", filename)
+}
@@ -0,0 +1,38 @@
+package assert
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUpdateMode(t *testing.T) {
+	os.Setenv("GO_ASSERT_UPDATE", "1")
+	defer os.Unsetenv("GO_ASSERT_UPDATE")
+	if !updateMode() {
+		t.Errorf("update mode not enabled")
+	}
+}
+
+func TestEqualFileUpdate(t *testing.T) {
+	os.Setenv("GO_ASSERT_UPDATE", "1")
+	defer os.Unsetenv("GO_ASSERT_UPDATE")
+	New(t).ItTmp("update", func(a *Assert, dir string) {
+		file := filepath.Join(dir, "a", "b", "out.golden")
+		isAssert(t, a.EqualFile("new content", file))
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "new content" {
+			t.Errorf("got: %s, exp: new content", data)
+		}
+		goldens.Lock()
+		files := goldens.updated["TestEqualFileUpdate"]
+		goldens.Unlock()
+		if len(files) != 1 || files[0] != file {
+			t.Errorf("got: %v, exp: [%s]", files, file)
+		}
+	})
+}
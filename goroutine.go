@@ -0,0 +1,188 @@
+package assert
+
+import (
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultLeakGrace is the default maximum time waited for the end of the
+// goroutines created by a subtest (see SetLeakGrace).
+const defaultLeakGrace = time.Second
+
+// createdBy is the line of a stacktrace with the creator of the goroutine.
+// The identifier of the creator is printed since Go 1.21.
+var createdBy = regexp.MustCompile(`(?m)^created by .* in goroutine (\d+)$`)
+
+// unknownParent is the parent of the goroutines created by a goroutine not
+// printed in the stacktrace (before Go 1.21).
+const unknownParent = -1
+
+// defaultAllowed are the goroutines always ignored by the leak check: the
+// tests executed in parallel and the goroutines of the standard library.
+var defaultAllowed = []*regexp.Regexp{
+	regexp.MustCompile(`testing\.tRunner`),
+	regexp.MustCompile(`testing\.\(\*[TBF]\)\.Run`),
+	regexp.MustCompile(`os/signal\.(signal_recv|loop)`),
+	regexp.MustCompile(`runtime\.ensureSigM`),
+}
+
+// goroutine is a goroutine of a stack dump.
+type goroutine struct {
+	id     int
+	parent int
+	stack  string
+}
+
+// goroutines returns the goroutines, except the current one.
+func goroutines() []goroutine {
+	_, others := stackDump()
+	return others
+}
+
+// currentGoroutine returns the identifier of the current goroutine.
+func currentGoroutine() int {
+	current, _ := stackDump()
+	return current.id
+}
+
+// stackDump returns the current goroutine and the other goroutines.
+func stackDump() (goroutine, []goroutine) {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	var (
+		current goroutine
+		res     []goroutine
+	)
+	for i, stack := range strings.Split(string(buf), "\n\n") {
+		fields := strings.Fields(stack)
+		if len(fields) < 2 || fields[0] != "goroutine" {
+			continue
+		}
+		id, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		g := goroutine{id: id, stack: stack}
+		if m := createdBy.FindStringSubmatch(stack); m != nil {
+			g.parent, _ = strconv.Atoi(m[1])
+		} else if strings.Contains(stack, "\ncreated by ") {
+			g.parent = unknownParent
+		}
+		if i == 0 {
+			current = g
+			continue
+		}
+		res = append(res, g)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].id < res[j].id })
+	return current, res
+}
+
+// snapshot is the state of the goroutines at the start of a subtest: only the
+// new goroutines created by the root goroutine of the subtest, directly or by
+// its descendants, belong to the subtest. The goroutines of the other tests
+// executed in parallel are ignored.
+type snapshot struct {
+	root    int
+	before  map[int]bool
+	parents map[int]int
+}
+
+// takeSnapshot returns the snapshot of the goroutines, the root is the current
+// goroutine.
+func takeSnapshot() *snapshot {
+	current, others := stackDump()
+	s := &snapshot{root: current.id, before: map[int]bool{}, parents: map[int]int{}}
+	for _, g := range others {
+		s.before[g.id] = true
+	}
+	return s
+}
+
+// newGoroutines returns the goroutines created since the snapshot by the
+// subtest and that are not allowed.
+//
+// The creators of the goroutines are recorded at each call: a goroutine
+// created by an intermediate goroutine that ended before any call is not
+// found.
+func (s *snapshot) newGoroutines(allowed []*regexp.Regexp) []goroutine {
+	gs := goroutines()
+	for _, g := range gs {
+		s.parents[g.id] = g.parent
+	}
+	var res []goroutine
+	for _, g := range gs {
+		if s.before[g.id] || !s.descendant(g.id) || matchAny(allowed, g.stack) {
+			continue
+		}
+		res = append(res, g)
+	}
+	return res
+}
+
+// descendant returns true if the goroutine id is created by the root
+// goroutine, directly or by its new descendants. If the creator is unknown,
+// all the new goroutines belong to the subtest, even the goroutines of the
+// tests executed in parallel.
+func (s *snapshot) descendant(id int) bool {
+	for i := 0; i <= len(s.parents); i++ {
+		parent, ok := s.parents[id]
+		if !ok || parent == 0 || s.before[parent] {
+			return false
+		}
+		if parent == s.root || parent == unknownParent {
+			return true
+		}
+		id = parent
+	}
+	return false
+}
+
+// matchAny returns true if s matches one of the patterns.
+func matchAny(patterns []*regexp.Regexp, s string) bool {
+	for _, p := range patterns {
+		if p.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLeaks fails the test if goroutines created by the subtest since the
+// snapshot are still alive after the grace period.
+func (a *Assert) checkLeaks(s *snapshot) {
+	cfg := a.conf()
+	allowed := append(cfg.allowed[:len(cfg.allowed):len(cfg.allowed)], defaultAllowed...)
+	deadline := time.Now().Add(cfg.grace)
+	leaked := s.newGoroutines(allowed)
+	for len(leaked) > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		leaked = s.newGoroutines(allowed)
+	}
+	if len(leaked) == 0 {
+		return
+	}
+	// The check is executed at the end of the subtest, even after a fatal
+	// error, so it must not stop the goroutine again.
+	a.SetFatal(false).errorMessage("LeakCheck", nil, len(leaked), "%d goroutines leaked:\n%s\n", len(leaked), stacks(leaked))()
+}
+
+// stacks joins the stacktraces of the goroutines.
+func stacks(gs []goroutine) string {
+	res := make([]string, len(gs))
+	for i, g := range gs {
+		res[i] = g.stack
+	}
+	return strings.Join(res, "\n\n")
+}
@@ -0,0 +1,84 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGoroutines(t *testing.T) {
+	done := make(chan bool)
+	defer close(done)
+	spawn := make(chan func())
+	defer close(spawn)
+	go func() {
+		for fn := range spawn {
+			go fn()
+		}
+	}()
+	s := takeSnapshot()
+	go func() {
+		go func() { <-done }()
+		<-done
+	}()
+	// created by a goroutine of another test
+	spawn <- func() { <-done }
+	time.Sleep(10 * time.Millisecond)
+	leaked := s.newGoroutines(nil)
+	if len(leaked) != 2 || !strings.Contains(leaked[0].stack, "TestGoroutines") {
+		t.Errorf("got: %v, exp: 2 goroutines", leaked)
+	}
+	if s.root != currentGoroutine() {
+		t.Errorf("got root: %d, exp: %d", s.root, currentGoroutine())
+	}
+}
+
+func TestGoroutinesUnknownParent(t *testing.T) {
+	s := &snapshot{root: 1, before: map[int]bool{2: true}, parents: map[int]int{3: unknownParent, 4: 3, 5: 2}}
+	for id, exp := range map[int]bool{3: true, 4: true, 5: false} {
+		if got := s.descendant(id); got != exp {
+			t.Errorf("goroutine %d: got %v, exp %v", id, got, exp)
+		}
+	}
+}
+
+func TestAssertLeakCheck(t *testing.T) {
+	isAssert(t, New(t).SetLeakCheck(true).It("no leak", func(a *Assert) {
+		done := make(chan bool)
+		go func() { close(done) }()
+		<-done
+	}))
+}
+
+func TestCheckLeaks(t *testing.T) {
+	done := make(chan bool)
+	defer close(done)
+	tb := &fakeTB{TB: t}
+	a := New(tb).SetLeakCheck(true)
+	before := takeSnapshot()
+	go func() { <-done }()
+	a.AllowGoroutines(`TestCheckLeaks\.func`).checkLeaks(before)
+	if tb.failed {
+		t.Errorf("allowed goroutine reported")
+	}
+	a.set(func(c *config) { c.allowed = nil })
+	a.checkLeaks(before)
+	if !tb.failed {
+		t.Errorf("leak not detected")
+	}
+}
+
+func TestCheckLeaksGrace(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	before := takeSnapshot()
+	go func() { time.Sleep(50 * time.Millisecond) }()
+	New(tb).checkLeaks(before)
+	if tb.failed {
+		t.Errorf("goroutine ended in the grace period reported")
+	}
+	go func() { time.Sleep(50 * time.Millisecond) }()
+	New(tb).SetLeakGrace(0).checkLeaks(before)
+	if !tb.failed {
+		t.Errorf("leak not detected without grace period")
+	}
+}
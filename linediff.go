@@ -0,0 +1,244 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultDiffContext is the default number of context lines of the line diffs.
+const defaultDiffContext = 3
+
+// maxDiffEdits is the maximum number of changed lines of a line diff: the
+// texts with more changes are showed without diff.
+const maxDiffEdits = 1000
+
+// edit is an operation of a line diff: ' ' keeps, '-' deletes the line exp of
+// the expected text, '+' inserts the line got of the got text.
+type edit struct {
+	op       byte
+	exp, got int
+}
+
+// myers returns the shortest edit script to transform a into b with the Myers
+// algorithm, or nil if there are more than maxDiffEdits changed lines. The
+// trace keeps only the diagonals -d..d of each step d.
+func myers(a, b []string) []edit {
+	n, m := len(a), len(b)
+	max := n + m
+	if max > maxDiffEdits {
+		max = maxDiffEdits
+	}
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	var trace [][]int
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b, d)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack builds the edit script from the trace of the Myers algorithm.
+func backtrack(trace [][]int, a, b []string, d int) []edit {
+	x, y := len(a), len(b)
+	var edits []edit
+	for ; d > 0; d-- {
+		// the diagonal k is at the index k+d of the trace
+		v := func(k int) int { return trace[d][k+d] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v(k-1) < v(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{' ', x, y})
+		}
+		if x == prevX {
+			y--
+			edits = append(edits, edit{'+', x, y})
+		} else {
+			x--
+			edits = append(edits, edit{'-', x, y})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		edits = append(edits, edit{' ', x, y})
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// isMultiline returns true if the value is a string with several lines.
+func isMultiline(v interface{}) bool {
+	s, ok := v.(string)
+	return ok && strings.Contains(s, "\n")
+}
+
+// lineDiff returns the unified diff of two texts with the line numbers and n
+// context lines. The changes inside the modified lines are highlighted:
+//
+//	Diff (-exp +got):
+//	@@ -1,3 +1,3 @@
+//	  1 1 aaa
+//	- 2   bbb
+//	+   2 bbc
+//	        ^
+//	  3 3 ccc
+//
+// The texts with more than maxDiffEdits changed lines are showed without diff.
+func lineDiff(exp, got string, n int) string {
+	a, b := strings.Split(exp, "\n"), strings.Split(got, "\n")
+	edits := myers(a, b)
+	if edits == nil {
+		return fmt.Sprintf("Exp:\n%s\nGot:\n%s\n", exp, got)
+	}
+	w := len(fmt.Sprint(len(a)))
+	if l := len(fmt.Sprint(len(b))); l > w {
+		w = l
+	}
+	blank := strings.Repeat(" ", w)
+
+	var buf strings.Builder
+	buf.WriteString("Diff (-exp +got):\n")
+	for _, h := range hunks(edits, n) {
+		hunk := edits[h[0]:h[1]]
+		ea, eb := hunkRange(hunk, func(e edit) bool { return e.op != '+' }, func(e edit) int { return e.exp })
+		ga, gb := hunkRange(hunk, func(e edit) bool { return e.op != '-' }, func(e edit) int { return e.got })
+		fmt.Fprintf(&buf, "@@ -%d,%d +%d,%d @@\n", ea, eb, ga, gb)
+		for i := 0; i < len(hunk); {
+			if hunk[i].op == ' ' {
+				e := hunk[i]
+				fmt.Fprintf(&buf, "  %*d %*d %s\n", w, e.exp+1, w, e.got+1, a[e.exp])
+				i++
+				continue
+			}
+			var dels, ins []edit
+			for ; i < len(hunk) && hunk[i].op == '-'; i++ {
+				dels = append(dels, hunk[i])
+			}
+			for ; i < len(hunk) && hunk[i].op == '+'; i++ {
+				ins = append(ins, hunk[i])
+			}
+			for _, e := range dels {
+				fmt.Fprintf(&buf, "- %*d %s %s\n", w, e.exp+1, blank, a[e.exp])
+			}
+			for j, e := range ins {
+				fmt.Fprintf(&buf, "+ %s %*d %s\n", blank, w, e.got+1, b[e.got])
+				if j < len(dels) {
+					buf.WriteString(highlight(a[dels[j].exp], b[e.got], 2*w+4))
+				}
+			}
+		}
+	}
+	return buf.String()
+}
+
+// hunks groups the changes of the edit script with n context lines. It returns
+// the start and the end indexes of each hunk.
+func hunks(edits []edit, n int) [][2]int {
+	if n < 0 {
+		n = 0
+	}
+	var res [][2]int
+	for i := 0; i < len(edits); i++ {
+		if edits[i].op == ' ' {
+			continue
+		}
+		start := i - n
+		if start < 0 {
+			start = 0
+		}
+		end := i + 1
+		for j := i + 1; j < len(edits) && j <= end+2*n; j++ {
+			if edits[j].op != ' ' {
+				end = j + 1
+			}
+		}
+		i = end - 1
+		end += n
+		if end > len(edits) {
+			end = len(edits)
+		}
+		if len(res) > 0 && start <= res[len(res)-1][1] {
+			res[len(res)-1][1] = end
+			continue
+		}
+		res = append(res, [2]int{start, end})
+	}
+	return res
+}
+
+// hunkRange returns the first line number and the number of lines of a side of
+// the hunk.
+func hunkRange(hunk []edit, in func(edit) bool, line func(edit) int) (int, int) {
+	first, count := -1, 0
+	for _, e := range hunk {
+		if in(e) {
+			if first < 0 {
+				first = line(e)
+			}
+			count++
+		}
+	}
+	if first < 0 {
+		// Empty side, the line before the hunk.
+		return line(hunk[0]), 0
+	}
+	return first + 1, count
+}
+
+// highlight returns a line with the markers ^ under the characters of got
+// that differ from exp. indent is the width of the diff line prefix.
+func highlight(exp, got string, indent int) string {
+	re, rg := []rune(exp), []rune(got)
+	p := 0
+	for p < len(re) && p < len(rg) && re[p] == rg[p] {
+		p++
+	}
+	s := 0
+	for s < len(re)-p && s < len(rg)-p && re[len(re)-1-s] == rg[len(rg)-1-s] {
+		s++
+	}
+	var buf strings.Builder
+	buf.WriteString(strings.Repeat(" ", indent))
+	for _, r := range rg[:p] {
+		if r == '\t' {
+			buf.WriteRune('\t')
+		} else {
+			buf.WriteRune(' ')
+		}
+	}
+	n := len(rg) - p - s
+	if n < 1 {
+		n = 1
+	}
+	buf.WriteString(strings.Repeat("^", n))
+	buf.WriteString("\n")
+	return buf.String()
+}
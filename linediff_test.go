@@ -0,0 +1,97 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMyers(t *testing.T) {
+	fixtures := []struct{ A, B string }{
+		{"", ""},
+		{"a b c", "a b c"},
+		{"a b c a b b a", "c b a b a c"},
+		{"", "a b"},
+		{"a b", ""},
+		{"x a b", "a b y"},
+	}
+	for _, fix := range fixtures {
+		a, b := strings.Fields(fix.A), strings.Fields(fix.B)
+		var ra, rb []string
+		for _, e := range myers(a, b) {
+			if e.op != '+' {
+				ra = append(ra, a[e.exp])
+			}
+			if e.op != '-' {
+				rb = append(rb, b[e.got])
+			}
+			if e.op == ' ' && a[e.exp] != b[e.got] {
+				t.Errorf("%q -> %q: invalid kept line %d, %d", fix.A, fix.B, e.exp, e.got)
+			}
+		}
+		if strings.Join(ra, " ") != fix.A || strings.Join(rb, " ") != fix.B {
+			t.Errorf("%q -> %q: got %q -> %q", fix.A, fix.B, ra, rb)
+		}
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	exp := "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\nl9\nl10\nl11"
+	got := "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\nl9\nl10\nl11 changed"
+	d := lineDiff(exp, got, 1)
+	want := `Diff (-exp +got):
+@@ -10,2 +10,2 @@
+  10 10 l10
+- 11    l11
++    11 l11 changed
+           ^^^^^^^^
+`
+	if d != want {
+		t.Errorf("got:\n%s\nexp:\n%s", d, want)
+	}
+}
+
+func TestHunks(t *testing.T) {
+	a := strings.Fields("a b c d e f g h i j")
+	b := strings.Fields("a X c d e f g h Y j")
+	if h := hunks(myers(a, b), 1); len(h) != 2 {
+		t.Errorf("got: %v, exp 2 hunks", h)
+	}
+	if h := hunks(myers(a, b), 3); len(h) != 1 {
+		t.Errorf("got: %v, exp 1 hunk", h)
+	}
+	if h := hunks(myers(a, b), -1); len(h) != 2 || h[0] != [2]int{1, 3} || h[1] != [2]int{9, 11} {
+		t.Errorf("got: %v, exp 2 hunks without context", h)
+	}
+}
+
+func TestLineDiffMax(t *testing.T) {
+	var a, b []string
+	for i := 0; i < maxDiffEdits; i++ {
+		a = append(a, "a")
+		b = append(b, "b")
+	}
+	if e := myers(a, b); e != nil {
+		t.Errorf("got: %d edits, exp nil", len(e))
+	}
+	exp, got := strings.Join(a, "\n"), strings.Join(b, "\n")
+	if d := lineDiff(exp, got, 3); d != "Exp:\n"+exp+"\nGot:\n"+got+"\n" {
+		t.Errorf("invalid diff: %.100s", d)
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	if h := highlight("\tabcd", "\tabXd", 0); h != "\t  ^\n" {
+		t.Errorf("got: %q", h)
+	}
+	if h := highlight("abcd", "abd", 2); h != "    ^\n" {
+		t.Errorf("got: %q", h)
+	}
+}
+
+func TestAssertEqualMultiline(t *testing.T) {
+	b, got := failures(t)
+	b.SetDiffContext(0).Equal("a\nb\nc", "a\nB\nc")
+	if len(*got) != 1 || !strings.HasPrefix((*got)[0].Text, "Error:\nDiff (-exp +got):\n@@ -2,1 +2,1 @@\n- 2   b\n+   2 B\n") {
+		t.Errorf("invalid failures: %v", *got)
+	}
+}
@@ -0,0 +1,154 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// HasKey asserts that the map m contains the key.
+func (a *Assert) HasKey(m, key interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		v := mapOf(m)
+		if !mapIndex(v, key).IsValid() {
+			a.errorMessage("HasKey", key, m, "Missing key: %#v\nKeys: %s\n", key, formatKeys(v))(msg...)
+		}
+	})
+}
+
+// NotHasKey asserts that the map m does not contain the key.
+func (a *Assert) NotHasKey(m, key interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if value := mapIndex(mapOf(m), key); value.IsValid() {
+			a.errorMessage("NotHasKey", key, m, "Unexpected key: %#v\nValue: %s\n", key, formatValue(value))(msg...)
+		}
+	})
+}
+
+// EqualMap asserts that the maps are deeply equal. If the assertion fails the
+// message shows the sorted keys grouped by differences:
+//
+//	Error:
+//	  Missing keys:
+//	    "c": 3
+//	  Unexpected keys:
+//	    "d": 4
+//	  Changed keys:
+//	    "a": exp 1 got 2
+//	    "bob":
+//	      .Address.Zip: exp "1000" got "1001"
+func (a *Assert) EqualMap(exp, got interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		e, g := mapOf(exp), mapOf(got)
+		if e.Type() != g.Type() {
+			a.errorMessage("EqualMap", exp, got, "Exp type: %s\nGot type: %s\n", e.Type(), g.Type())(msg...)
+			return
+		}
+		if diff := mapDiff(e, g, true); diff != "" {
+			a.errorMessage("EqualMap", exp, got, "%s", diff)(msg...)
+		}
+	})
+}
+
+// MapContains asserts that the entries of the map subset are in the map m.
+// If the assertion fails the message shows the missing and the changed keys
+// (see EqualMap).
+func (a *Assert) MapContains(m, subset interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if diff := mapDiff(mapOf(subset), mapOf(m), false); diff != "" {
+			a.errorMessage("MapContains", subset, m, "%s", diff)(msg...)
+		}
+	})
+}
+
+// mapOf returns the reflect value of the map m.
+func mapOf(m interface{}) reflect.Value {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map {
+		panic(fmt.Sprintf("a map is required: %T", m))
+	}
+	return v
+}
+
+// mapIndex returns the value of the key in the map m, or an invalid value if
+// the key is missing.
+func mapIndex(m reflect.Value, key interface{}) reflect.Value {
+	k := reflect.ValueOf(key)
+	if !k.IsValid() {
+		switch m.Type().Key().Kind() {
+		case reflect.Interface, reflect.Ptr:
+			k = reflect.Zero(m.Type().Key())
+		default:
+			return reflect.Value{}
+		}
+	}
+	if !k.Type().AssignableTo(m.Type().Key()) {
+		return reflect.Value{}
+	}
+	return m.MapIndex(k)
+}
+
+// sortedKeys returns the keys of the map m in the order of sortValues.
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sortValues(keys)
+	return keys
+}
+
+// formatKeys formats the sorted keys of the map m.
+func formatKeys(m reflect.Value) string {
+	var keys []string
+	for _, k := range sortedKeys(m) {
+		keys = append(keys, formatValue(k))
+	}
+	return "[" + strings.Join(keys, " ") + "]"
+}
+
+// mapDiff returns the differences between the maps exp and got, grouped by
+// missing, unexpected (if unexpected is true) and changed keys, or an empty
+// string if there is no difference.
+func mapDiff(exp, got reflect.Value, unexpected bool) string {
+	var missing, extra, changed []string
+	for _, k := range sortedKeys(exp) {
+		e, g := exp.MapIndex(k), mapIndex(got, k.Interface())
+		switch {
+		case !g.IsValid():
+			missing = append(missing, fmt.Sprintf("%s: %s", formatValue(k), formatValue(e)))
+		case !reflect.DeepEqual(e.Interface(), g.Interface()):
+			changed = append(changed, changedKey(k, e, g))
+		}
+	}
+	if unexpected {
+		for _, k := range sortedKeys(got) {
+			if !mapIndex(exp, k.Interface()).IsValid() {
+				extra = append(extra, fmt.Sprintf("%s: %s", formatValue(k), formatValue(got.MapIndex(k))))
+			}
+		}
+	}
+	var b strings.Builder
+	for _, group := range []struct {
+		title string
+		lines []string
+	}{
+		{"Missing keys", missing},
+		{"Unexpected keys", extra},
+		{"Changed keys", changed},
+	} {
+		if len(group.lines) > 0 {
+			fmt.Fprintf(&b, "%s:\n  %s\n", group.title, strings.Join(group.lines, "\n  "))
+		}
+	}
+	return b.String()
+}
+
+// changedKey formats the key of a changed value with the nested differences.
+func changedKey(k, exp, got reflect.Value) string {
+	diffs := diffValues(exp.Interface(), got.Interface())
+	if len(diffs) == 0 {
+		return fmt.Sprintf("%s: exp %s got %s", formatValue(k), formatValue(exp), formatValue(got))
+	}
+	if len(diffs) == 1 && strings.HasPrefix(diffs[0], ".: ") {
+		return formatValue(k) + ": " + strings.TrimPrefix(diffs[0], ".: ")
+	}
+	return formatValue(k) + ":\n    " + strings.Join(diffs, "\n    ")
+}
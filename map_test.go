@@ -0,0 +1,70 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+type mapAddress struct {
+	City, Zip string
+}
+
+func TestHasKey(t *testing.T) {
+	m := map[string]int{"b": 2, "a": 1}
+	a := New(t)
+	isAssert(t, a.HasKey(m, "a").NotHasKey(m, "c").NotHasKey(m, 3).NotHasKey(m, nil))
+	isAssert(t, a.HasKey(map[interface{}]int{nil: 1}, nil))
+
+	b, got := failures(t)
+	b.HasKey(m, "c").NotHasKey(m, "b")
+	if len(*got) != 2 {
+		t.Fatalf("got: %d failures, exp: 2", len(*got))
+	}
+	if !strings.HasSuffix((*got)[0].Text, "Missing key: \"c\"\nKeys: [\"a\" \"b\"]\n") {
+		t.Errorf("invalid message: %s", (*got)[0].Text)
+	}
+	if !strings.HasSuffix((*got)[1].Text, "Unexpected key: \"b\"\nValue: 2\n") {
+		t.Errorf("invalid message: %s", (*got)[1].Text)
+	}
+}
+
+func TestEqualMap(t *testing.T) {
+	exp := map[string]interface{}{
+		"a":   1,
+		"bob": mapAddress{"Paris", "1000"},
+		"c":   3,
+	}
+	got := map[string]interface{}{
+		"a":   2,
+		"bob": mapAddress{"Paris", "1001"},
+		"d":   4,
+	}
+	a := New(t)
+	isAssert(t, a.EqualMap(exp, exp).MapContains(exp, map[string]interface{}{"c": 3}))
+
+	b, reported := failures(t)
+	b.EqualMap(exp, got).MapContains(got, map[string]interface{}{"a": 2, "c": 3, "d": 5})
+	b.EqualMap(map[string]int{}, map[string]string{})
+	if len(*reported) != 3 {
+		t.Fatalf("got: %d failures, exp: 3", len(*reported))
+	}
+	msg := `Missing keys:
+  "c": 3
+Unexpected keys:
+  "d": 4
+Changed keys:
+  "a": exp 1 got 2
+  "bob":
+    .Zip: exp "1000" got "1001"
+`
+	if !strings.HasSuffix((*reported)[0].Text, msg) {
+		t.Errorf("got:\n%s\nexp:\n%s", (*reported)[0].Text, msg)
+	}
+	msg = "Missing keys:\n  \"c\": 3\nChanged keys:\n  \"d\": exp 5 got 4\n"
+	if !strings.HasSuffix((*reported)[1].Text, msg) {
+		t.Errorf("got:\n%s\nexp:\n%s", (*reported)[1].Text, msg)
+	}
+	if !strings.Contains((*reported)[2].Text, "Exp type: map[string]int\nGot type: map[string]string\n") {
+		t.Errorf("invalid message: %s", (*reported)[2].Text)
+	}
+}
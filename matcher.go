@@ -0,0 +1,259 @@
+package assert
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// Matcher is a condition on a value used by That.
+//
+// The matchers can be combined with Not, AllOf and AnyOf:
+//
+//	a.That(s, T.AnyOf(T.AllOf(T.Not(T.IsNil()), T.Matches(`^[a-z]+$`)), T.IsEmpty()))
+type Matcher interface {
+	// Match returns true if the value satisfies the condition.
+	Match(got interface{}) bool
+	// Describe describes the condition, for example: equal to 3.
+	Describe() string
+}
+
+// That asserts that the got value satisfies the matcher.
+//
+// If the assertion fails then a message shows the description of the matcher:
+//
+//	Error:
+//	  Exp: matches the regex ^[a-z]+$
+//	  Got: Bob
+func (a *Assert) That(got interface{}, m Matcher, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if !m.Match(got) {
+			a.errorMessage("That", m.Describe(), got, "Exp: %s\nGot: %+v\n", m.Describe(), got)(msg...)
+		}
+	})
+}
+
+// matcher is a Matcher defined by a function.
+type matcher struct {
+	desc string
+	fn   func(interface{}) bool
+}
+
+func (m matcher) Match(got interface{}) bool { return m.fn(got) }
+func (m matcher) Describe() string           { return m.desc }
+
+// NewMatcher creates a Matcher with a description and a function.
+//
+// Example:
+//
+//	even := T.NewMatcher("even number", func(got interface{}) bool {
+//		n, ok := got.(int)
+//		return ok && n%2 == 0
+//	})
+//	a.That(4, even)
+func NewMatcher(description string, fn func(got interface{}) bool) Matcher {
+	return matcher{description, fn}
+}
+
+// Not inverts a matcher.
+func Not(m Matcher) Matcher {
+	return matcher{"not " + m.Describe(), func(got interface{}) bool {
+		return !m.Match(got)
+	}}
+}
+
+// AllOf matches if all the matchers match.
+func AllOf(ms ...Matcher) Matcher {
+	return matcher{describeAll(ms, " and "), func(got interface{}) bool {
+		for _, m := range ms {
+			if !m.Match(got) {
+				return false
+			}
+		}
+		return true
+	}}
+}
+
+// AnyOf matches if at least one matcher matches.
+func AnyOf(ms ...Matcher) Matcher {
+	return matcher{describeAll(ms, " or "), func(got interface{}) bool {
+		for _, m := range ms {
+			if m.Match(got) {
+				return true
+			}
+		}
+		return false
+	}}
+}
+
+// describeAll joins the descriptions of the matchers.
+func describeAll(ms []Matcher, sep string) string {
+	descs := make([]string, len(ms))
+	for i, m := range ms {
+		descs[i] = m.Describe()
+	}
+	return "(" + strings.Join(descs, sep) + ")"
+}
+
+// EqualTo is the matcher version of Equal.
+func EqualTo(exp interface{}) Matcher {
+	return matcher{fmt.Sprintf("equal to %+v", exp), func(got interface{}) bool {
+		return exp == got
+	}}
+}
+
+// DeepEqualTo is the matcher version of EqualDeep, EqualSlice and
+// EqualStringSlice.
+func DeepEqualTo(exp interface{}) Matcher {
+	return matcher{fmt.Sprintf("deep equal to %#v", exp), func(got interface{}) bool {
+		return reflect.DeepEqual(exp, got)
+	}}
+}
+
+// CloseTo is the matcher version of EqualFAbs.
+func CloseTo(exp, epsilon float64) Matcher {
+	desc := fmt.Sprintf("%f with an absolute tolerance: %f", exp, epsilon)
+	return matcher{desc, func(got interface{}) bool {
+		f, ok := toFloat(got)
+		return ok && compareAbs(exp, f, epsilon)
+	}}
+}
+
+// RelCloseTo is the matcher version of EqualFRel.
+func RelCloseTo(exp, epsilon float64) Matcher {
+	desc := fmt.Sprintf("%f with an relative tolerance: %f", exp, epsilon)
+	return matcher{desc, func(got interface{}) bool {
+		f, ok := toFloat(got)
+		return ok && compareRel(exp, f, epsilon)
+	}}
+}
+
+// IsTrue is the matcher version of True.
+func IsTrue() Matcher {
+	return EqualTo(true)
+}
+
+// IsFalse is the matcher version of False.
+func IsFalse() Matcher {
+	return EqualTo(false)
+}
+
+// IsNil is the matcher version of Nil. Use Not(IsNil()) for NotNil.
+func IsNil() Matcher {
+	return matcher{"nil", isNil}
+}
+
+// IsEmpty matches the zero values and the empty strings, slices, maps, arrays
+// and channels.
+func IsEmpty() Matcher {
+	return matcher{"empty", func(got interface{}) bool {
+		if got == nil {
+			return true
+		}
+		v := reflect.ValueOf(got)
+		switch v.Kind() {
+		case reflect.String, reflect.Slice, reflect.Map, reflect.Array, reflect.Chan:
+			return v.Len() == 0
+		}
+		return v.IsZero()
+	}}
+}
+
+// HasErrorMessage is the matcher version of Error.
+func HasErrorMessage(errFormat string, errA ...interface{}) Matcher {
+	exp := fmt.Sprintf(errFormat, errA...)
+	return matcher{fmt.Sprintf("error with message: %s", exp), func(got interface{}) bool {
+		err, ok := got.(error)
+		return ok && err != nil && err.Error() == exp
+	}}
+}
+
+// Matches is the matcher version of Match.
+func Matches(pattern string) Matcher {
+	re := regexp.MustCompile(pattern)
+	return matcher{fmt.Sprintf("matches the regex %s", pattern), func(got interface{}) bool {
+		s, ok := got.(string)
+		return ok && re.MatchString(s)
+	}}
+}
+
+// HasFileContent is the matcher version of EqualFile.
+func HasFileContent(filename string) Matcher {
+	fi, err := ioutil.ReadFile(filename)
+	if err != nil {
+		panic(err)
+	}
+	return EqualTo(string(fi))
+}
+
+// HasTemplateContent is the matcher version of EqualTemplate.
+func HasTemplateContent(filename string, data interface{}) Matcher {
+	tpl, err := template.ParseFiles(filename)
+	if err != nil {
+		panic(err)
+	}
+	var buf strings.Builder
+	if err := tpl.Execute(&buf, data); err != nil {
+		panic(err)
+	}
+	exp := strings.TrimSpace(buf.String())
+	return matcher{fmt.Sprintf("equal to %s", exp), func(got interface{}) bool {
+		s, ok := got.(string)
+		return ok && strings.TrimSpace(s) == exp
+	}}
+}
+
+// IsFile is the matcher version of IsFile.
+func IsFile() Matcher {
+	return matcher{"a file", func(got interface{}) bool {
+		s, ok := got.(string)
+		return ok && isFile(s)
+	}}
+}
+
+// IsDir is the matcher version of IsDir.
+func IsDir() Matcher {
+	return matcher{"a directory", func(got interface{}) bool {
+		s, ok := got.(string)
+		return ok && isDir(s)
+	}}
+}
+
+// Exists matches the existing paths. Use Not(Exists()) for NotExists.
+func Exists() Matcher {
+	return matcher{"an existing path", func(got interface{}) bool {
+		s, ok := got.(string)
+		return ok && exists(s)
+	}}
+}
+
+// isNil returns true if v is nil or a nil pointer, slice, map, channel,
+// function or interface.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// toFloat converts a number to a float64.
+func toFloat(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
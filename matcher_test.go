@@ -0,0 +1,57 @@
+package assert
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAssertThat(t *testing.T) {
+	isAssert(t, New(t).That("adam", Matches(`^[a-z]+$`), "format str"))
+}
+
+func TestThatFailure(t *testing.T) {
+	b, got := failures(t)
+	b.That(3, AllOf(EqualTo(3), Not(EqualTo(3))))
+	if !b.t.(*fakeTB).failed {
+		t.Errorf("test not failed")
+	}
+	if len(*got) != 1 || (*got)[0].Text != "Error:\nExp: (equal to 3 and not equal to 3)\nGot: 3\n" {
+		t.Errorf("invalid failures: %v", *got)
+	}
+}
+
+func TestMatchers(t *testing.T) {
+	var nilPtr *int
+	fixtures := []struct {
+		M   Matcher
+		Got interface{}
+		Exp bool
+	}{
+		{EqualTo(3), 3, true},
+		{EqualTo(3), int64(3), false},
+		{DeepEqualTo([]int{1, 2}), []int{1, 2}, true},
+		{CloseTo(1.1, 0.2), 1.2, true},
+		{RelCloseTo(100, 0.01), 150, false},
+		{IsTrue(), true, true},
+		{IsFalse(), true, false},
+		{IsNil(), nilPtr, true},
+		{IsNil(), 3, false},
+		{IsEmpty(), "", true},
+		{IsEmpty(), []int{1}, false},
+		{HasErrorMessage("my message %d", 33), fmt.Errorf("my message 33"), true},
+		{HasFileContent("examples/testdata/ipsum.txt"), "Nulla facilisi.", true},
+		{HasTemplateContent("examples/testdata/temp.tpl", struct{ A, B string }{"aa", "bb"}), " aa - bb ", true},
+		{IsFile(), "examples/testdata/temp.tpl", true},
+		{IsDir(), "examples/testdata/temp.tpl", false},
+		{Exists(), "examples/testdata/blabla", false},
+		{Not(IsNil()), 3, true},
+		{AnyOf(IsEmpty(), Matches(`^[0-9]+$`)), "12", true},
+		{AnyOf(IsEmpty(), Matches(`^[0-9]+$`)), "a", false},
+		{NewMatcher("even", func(got interface{}) bool { return got.(int)%2 == 0 }), 4, true},
+	}
+	for i, fix := range fixtures {
+		if fix.M.Match(fix.Got) != fix.Exp {
+			t.Errorf("%d: %s, got: %#v, exp: %t", i, fix.M.Describe(), fix.Got, fix.Exp)
+		}
+	}
+}
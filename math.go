@@ -15,3 +15,9 @@ func compareRel(a, b, epsilon float64) bool {
 	largest := math.Max(math.Abs(a), math.Abs(b))
 	return diff <= largest*epsilon
 }
+
+// Comparison of a measure with a baseline and a relative epsilon: true if got
+// is greater than the baseline by more than the tolerance.
+func exceedsRel(base, got, epsilon float64) bool {
+	return got-base > math.Abs(base)*epsilon
+}
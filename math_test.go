@@ -21,3 +21,13 @@ func TestCompareRel(t *testing.T) {
 		t.Errorf("compareRel failed")
 	}
 }
+
+func TestExceedsRel(t *testing.T) {
+	if exceedsRel(100, 110, 0.2) || exceedsRel(100, 50, 0.2) {
+		t.Errorf("exceedsRel failed")
+	}
+
+	if !exceedsRel(100, 121, 0.2) || !exceedsRel(0, 1, 0.2) {
+		t.Errorf("exceedsRel failed")
+	}
+}
@@ -0,0 +1,272 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// Greater asserts that x > y. x and y are numbers or strings of the same type,
+// the values of different types and NaN fail the assertion.
+func (a *Assert) Greater(x, y interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		c, err := compareOrdered(x, y)
+		a.relation("Greater", x, ">", y, c, err, msg)
+	})
+}
+
+// GreaterOrEqual asserts that x >= y (see Greater).
+func (a *Assert) GreaterOrEqual(x, y interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		c, err := compareOrdered(x, y)
+		a.relation("GreaterOrEqual", x, ">=", y, c, err, msg)
+	})
+}
+
+// Less asserts that x < y (see Greater).
+func (a *Assert) Less(x, y interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		c, err := compareOrdered(x, y)
+		a.relation("Less", x, "<", y, c, err, msg)
+	})
+}
+
+// LessOrEqual asserts that x <= y (see Greater).
+func (a *Assert) LessOrEqual(x, y interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		c, err := compareOrdered(x, y)
+		a.relation("LessOrEqual", x, "<=", y, c, err, msg)
+	})
+}
+
+// InRange asserts that min <= v <= max (see Greater).
+func (a *Assert) InRange(v, min, max interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		cmin, err := compareOrdered(v, min)
+		cmax := 0
+		if err == nil {
+			cmax, err = compareOrdered(v, max)
+		}
+		a.inRange("InRange", v, min, max, cmin, cmax, err, msg)
+	})
+}
+
+// IsSorted asserts that the elements of the slice or the array s are sorted
+// in increasing order. The elements are numbers or strings (see Greater). If
+// the assertion fails the message shows the first element out of order:
+//
+//	Error:
+//	  Got: [1 2 5 3 4]
+//	   ┗━━━━━━━━━━━┛
+//	  Not sorted at index 3: 3 < 5
+func (a *Assert) IsSorted(s interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		checkSorted(a, "IsSorted", s, elementsOf(s), compareOrdered, msg)
+	})
+}
+
+// IsSortedBy asserts that the elements of the slice s are sorted by the
+// function less, similar to the function of sort.Slice (see IsSorted).
+func (a *Assert) IsSortedBy(s interface{}, less func(i, j int) bool, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		e := elementsOf(s)
+		for i := 1; i < len(e); i++ {
+			if less(i, i-1) {
+				a.errorMessage("IsSortedBy", nil, s, "%s", unsortedMessage(e, i, "sorted", fmt.Sprintf("%+v before %+v", e[i], e[i-1])))(msg...)
+				return
+			}
+		}
+	})
+}
+
+// Monotonic asserts that the elements of the slice or the array s are in
+// increasing or in decreasing order, the equal consecutive elements are
+// allowed (see IsSorted).
+func (a *Assert) Monotonic(s interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		checkMonotonic(a, "Monotonic", s, elementsOf(s), compareOrdered, msg)
+	})
+}
+
+// errNaN is the error of the comparisons with NaN.
+var errNaN = errors.New("NaN is not ordered")
+
+// compareOrdered returns -1, 0 or 1 if x is less, equal or greater than y
+// (see compare). It returns an error if the values are not numbers or strings
+// of the same type.
+func compareOrdered(x, y interface{}) (int, error) {
+	vx, vy := reflect.ValueOf(x), reflect.ValueOf(y)
+	if !vx.IsValid() || !vy.IsValid() || vx.Type() != vy.Type() {
+		return 0, fmt.Errorf("cannot compare %T and %T", x, y)
+	}
+	switch vx.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compare(vx.Int(), vy.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compare(vx.Uint(), vy.Uint())
+	case reflect.Float32, reflect.Float64:
+		return compare(vx.Float(), vy.Float())
+	case reflect.String:
+		return compare(vx.String(), vy.String())
+	}
+	return 0, fmt.Errorf("cannot compare the values of type %T", x)
+}
+
+// compare returns -1, 0 or 1 if x is less, equal or greater than y, or
+// errNaN if x or y is NaN.
+func compare[T Ordered](x, y T) (int, error) {
+	switch {
+	case x < y:
+		return -1, nil
+	case x > y:
+		return 1, nil
+	case x == y:
+		return 0, nil
+	}
+	return 0, errNaN
+}
+
+// negations contains the relation satisfied by the values if a relation
+// fails.
+var negations = map[string]string{"<": ">=", "<=": ">", ">": "<=", ">=": "<"}
+
+// relation reports a failure if the relation rel (<, <=, > or >=) does not
+// hold between x and y. c and err are the result of the comparison of x and
+// y.
+func (a *Assert) relation(name string, x interface{}, rel string, y interface{}, c int, err error, msg []interface{}) {
+	if err == nil && holds(c, rel) {
+		return
+	}
+	a.errorMessage(name, x, y, "%s", relationMessage(x, rel, y, err))(msg...)
+}
+
+// holds returns true if the relation rel holds for the result c of a
+// comparison.
+func holds(c int, rel string) bool {
+	switch rel {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	}
+	return c >= 0
+}
+
+// inRange reports a failure if v is not in the range [min, max]. cmin and
+// cmax are the comparisons of v with min and max, err the error of these
+// comparisons.
+func (a *Assert) inRange(name string, v, min, max interface{}, cmin, cmax int, err error, msg []interface{}) {
+	if err == nil && cmin >= 0 && cmax <= 0 {
+		return
+	}
+	a.errorMessage(name, [2]interface{}{min, max}, v, "%s", rangeMessage(v, min, max, cmin < 0, err))(msg...)
+}
+
+// checkSorted reports a failure if the elements of s are not sorted in
+// increasing order by the comparison function cmp. got is the value checked
+// by the assertion.
+func checkSorted[T any](a *Assert, name string, got interface{}, s []T, cmp func(x, y T) (int, error), msg []interface{}) {
+	i := unsortedIndex(s, func(x, prev T) bool {
+		c, err := cmp(prev, x)
+		return err != nil || c > 0
+	})
+	if i < 0 {
+		return
+	}
+	text := fmt.Sprintf("%+v < %+v", s[i], s[i-1])
+	if _, err := cmp(s[i-1], s[i]); err != nil {
+		text = err.Error()
+	}
+	a.errorMessage(name, nil, got, "%s", unsortedMessage(s, i, "sorted", text))(msg...)
+}
+
+// checkMonotonic reports a failure if the elements of s are not monotonic by
+// the comparison function cmp (see checkSorted).
+func checkMonotonic[T any](a *Assert, name string, got interface{}, s []T, cmp func(x, y T) (int, error), msg []interface{}) {
+	i, dir, err := monotonicIndex(s, cmp)
+	if i < 0 {
+		return
+	}
+	text := monotonicText(s[i], s[i-1], dir)
+	if err != nil {
+		text = err.Error()
+	}
+	a.errorMessage(name, nil, got, "%s", unsortedMessage(s, i, "monotonic", text))(msg...)
+}
+
+// relationMessage returns the message of a failed relation x rel y, err is
+// the error of the comparison.
+func relationMessage(x interface{}, rel string, y interface{}, err error) string {
+	if err != nil {
+		return fmt.Sprintf("Exp: %+v %s %+v\nGot: %s\n", x, rel, y, err)
+	}
+	return fmt.Sprintf("Exp: %+v %s %+v\nGot: %+v %s %+v\n", x, rel, y, x, negations[rel], y)
+}
+
+// rangeMessage returns the message of a value out of the range [min, max].
+func rangeMessage(v, min, max interface{}, below bool, err error) string {
+	exp := fmt.Sprintf("Exp: %+v <= %+v <= %+v\n", min, v, max)
+	switch {
+	case err != nil:
+		return fmt.Sprintf("%sGot: %s\n", exp, err)
+	case below:
+		return fmt.Sprintf("%sGot: %+v < %+v\n", exp, v, min)
+	}
+	return fmt.Sprintf("%sGot: %+v > %+v\n", exp, v, max)
+}
+
+// unsortedIndex returns the index of the first element less than the previous
+// one, or -1 if the elements are sorted.
+func unsortedIndex[T any](s []T, less func(x, y T) bool) int {
+	for i := 1; i < len(s); i++ {
+		if less(s[i], s[i-1]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// monotonicIndex returns the index of the first element that changes the
+// direction of the elements, or -1 if the elements are monotonic. The
+// direction is 1 for the increasing elements and -1 for the decreasing ones.
+// The error is the error of the comparison of the element at the index.
+func monotonicIndex[T any](s []T, cmp func(x, y T) (int, error)) (int, int, error) {
+	dir := 0
+	for i := 1; i < len(s); i++ {
+		c, err := cmp(s[i], s[i-1])
+		switch {
+		case err != nil:
+			return i, dir, err
+		case c == 0:
+		case dir == 0:
+			dir = c
+		case c != dir:
+			return i, dir, nil
+		}
+	}
+	return -1, dir, nil
+}
+
+// monotonicText describes the element x that changes the direction after
+// prev.
+func monotonicText(x, prev interface{}, dir int) string {
+	if dir > 0 {
+		return fmt.Sprintf("%+v < %+v in increasing order", x, prev)
+	}
+	return fmt.Sprintf("%+v > %+v in decreasing order", x, prev)
+}
+
+// unsortedMessage shows the elements with the marker until the element at
+// the index i (see EqualStringSlice) and the reason of the failure.
+func unsortedMessage[T any](s []T, i int, what, text string) string {
+	parts := formatElements(s)
+	end := utf8.RuneCountInString("Got: [")
+	for _, p := range parts[:i+1] {
+		end += utf8.RuneCountInString(p) + 1
+	}
+	return fmt.Sprintf("Got: [%s]\n ┗%s┛\nNot %s at index %d: %s\n", strings.Join(parts, " "), strings.Repeat("━", end-3), what, i, text)
+}
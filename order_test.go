@@ -0,0 +1,112 @@
+package assert
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestOrdered(t *testing.T) {
+	a := New(t)
+	isAssert(t, a.Greater(2, 1).GreaterOrEqual(2.5, 2.5).Less("a", "b").LessOrEqual(uint8(3), uint8(3)))
+	isAssert(t, a.InRange(5, 1, 5).InRange("b", "a", "c"))
+
+	b, got := failures(t)
+	b.Greater(3, 5).GreaterOrEqual(1, 2).Less(2, 2).LessOrEqual("b", "a")
+	b.InRange(0, 1, 5).InRange(7, 1, 5)
+	texts := []string{
+		"Exp: 3 > 5\nGot: 3 <= 5\n",
+		"Exp: 1 >= 2\nGot: 1 < 2\n",
+		"Exp: 2 < 2\nGot: 2 >= 2\n",
+		"Exp: b <= a\nGot: b > a\n",
+		"Exp: 1 <= 0 <= 5\nGot: 0 < 1\n",
+		"Exp: 1 <= 7 <= 5\nGot: 7 > 5\n",
+	}
+	if len(*got) != len(texts) {
+		t.Fatalf("got: %d failures, exp: %d", len(*got), len(texts))
+	}
+	for i, text := range texts {
+		if !strings.HasSuffix((*got)[i].Text, text) {
+			t.Errorf("got:\n%s\nexp:\n%s", (*got)[i].Text, text)
+		}
+	}
+}
+
+func TestOrderedInvalid(t *testing.T) {
+	nan := math.NaN()
+	b, got := failures(t)
+	b.Greater(1, int64(1)).Less(nil, 1).LessOrEqual(struct{}{}, struct{}{})
+	b.GreaterOrEqual(nan, 1.0).LessOrEqual(1.0, nan).InRange(nan, 0.0, 1.0).InRange(1, 0, "a")
+	GreaterOrEqualOf(b, nan, nan)
+	InRangeOf(b, nan, 0, 1)
+	texts := []string{
+		"Exp: 1 > 1\nGot: cannot compare int and int64\n",
+		"Exp: <nil> < 1\nGot: cannot compare <nil> and int\n",
+		"Exp: {} <= {}\nGot: cannot compare the values of type struct {}\n",
+		"Exp: NaN >= 1\nGot: NaN is not ordered\n",
+		"Exp: 1 <= NaN\nGot: NaN is not ordered\n",
+		"Exp: 0 <= NaN <= 1\nGot: NaN is not ordered\n",
+		"Exp: 0 <= 1 <= a\nGot: cannot compare int and string\n",
+		"Exp: NaN >= NaN\nGot: NaN is not ordered\n",
+		"Exp: 0 <= NaN <= 1\nGot: NaN is not ordered\n",
+	}
+	if len(*got) != len(texts) {
+		t.Fatalf("got: %d failures, exp: %d", len(*got), len(texts))
+	}
+	for i, text := range texts {
+		if !strings.HasSuffix((*got)[i].Text, text) {
+			t.Errorf("got:\n%s\nexp:\n%s", (*got)[i].Text, text)
+		}
+	}
+}
+
+func TestSorted(t *testing.T) {
+	a := New(t)
+	isAssert(t, a.IsSorted([]int{1, 2, 2, 3}).IsSorted([0]string{}).IsSorted([]float64{1}))
+	users := []string{"eve", "bob", "alice"}
+	isAssert(t, a.IsSortedBy(users, func(i, j int) bool { return len(users[i]) < len(users[j]) }))
+	isAssert(t, a.Monotonic([]int{1, 1, 2, 3}).Monotonic([]int{3, 3, 2}).Monotonic([]int{}))
+
+	b, got := failures(t)
+	b.IsSorted([]int{1, 2, 5, 3, 4})
+	b.IsSortedBy(users, func(i, j int) bool { return users[i] < users[j] })
+	b.Monotonic([]int{3, 3, 2, 4})
+	b.IsSorted([]float64{1, math.NaN(), 2}).Monotonic([]float64{2, math.NaN()}).IsSorted([]interface{}{1, "a"})
+	MonotonicOf(b, []float64{math.NaN(), 1})
+	texts := []string{
+		"Got: [1 2 5 3 4]\n ┗━━━━━━━━━━━┛\nNot sorted at index 3: 3 < 5\n",
+		"Got: [eve bob alice]\n ┗━━━━━━━━━━━┛\nNot sorted at index 1: bob before eve\n",
+		"Got: [3 3 2 4]\n ┗━━━━━━━━━━━┛\nNot monotonic at index 3: 4 > 2 in decreasing order\n",
+		"Got: [1 NaN 2]\n ┗━━━━━━━━━┛\nNot sorted at index 1: NaN is not ordered\n",
+		"Got: [2 NaN]\n ┗━━━━━━━━━┛\nNot monotonic at index 1: NaN is not ordered\n",
+		"Got: [1 a]\n ┗━━━━━━━┛\nNot sorted at index 1: cannot compare int and string\n",
+		"Got: [NaN 1]\n ┗━━━━━━━━━┛\nNot monotonic at index 1: NaN is not ordered\n",
+	}
+	if len(*got) != len(texts) {
+		t.Fatalf("got: %d failures, exp: %d", len(*got), len(texts))
+	}
+	for i, text := range texts {
+		if !strings.HasSuffix((*got)[i].Text, text) {
+			t.Errorf("got:\n%s\nexp:\n%s", (*got)[i].Text, text)
+		}
+	}
+}
+
+func TestSortedPointers(t *testing.T) {
+	p, q := &mapAddress{"Paris", "75000"}, &mapAddress{"Lyon", "69000"}
+	s := []*mapAddress{p, q}
+	b, got := failures(t)
+	b.IsSortedBy(s, func(i, j int) bool { return s[i].City < s[j].City })
+	IsSortedByOf(b, s, func(x, y *mapAddress) bool { return x.City < y.City })
+	line := fmt.Sprintf("Got: [%p %p]", p, q)
+	exp := fmt.Sprintf("%s\n ┗%s┛\nNot sorted at index 1: ", line, strings.Repeat("━", len(line)-3))
+	if len(*got) != 2 {
+		t.Fatalf("got: %d failures, exp: 2", len(*got))
+	}
+	for _, f := range *got {
+		if !strings.Contains(f.Text, exp) {
+			t.Errorf("got:\n%s\nexp:\n%s", f.Text, exp)
+		}
+	}
+}
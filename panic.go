@@ -0,0 +1,132 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"runtime/debug"
+)
+
+// Recovered is a panic recovered by an assertion.
+type Recovered struct {
+	// Value is the value passed to panic.
+	Value interface{}
+	// Stack is the stacktrace of the goroutine at the panic.
+	Stack []byte
+}
+
+// Message returns the message of the panic: the message of the error or the
+// formatted value.
+func (r *Recovered) Message() string {
+	if err, ok := r.Value.(error); ok {
+		return err.Error()
+	}
+	return fmt.Sprint(r.Value)
+}
+
+// recoverPanic executes fn and returns the recovered panic, or nil if fn does
+// not panic.
+func recoverPanic(fn func()) (r *Recovered) {
+	panicked := true
+	defer func() {
+		if panicked {
+			r = &Recovered{Value: recover(), Stack: debug.Stack()}
+		}
+	}()
+	fn()
+	panicked = false
+	return nil
+}
+
+// Panics asserts that fn panics.
+func (a *Assert) Panics(fn func(), msg ...interface{}) *Assert {
+	a.RecoverPanic(fn, msg...)
+	return a
+}
+
+// RecoverPanic is similar to Panics but it returns the recovered panic to
+// continue the assertions, or nil if fn does not panic.
+//
+// Example:
+//
+//	r := a.RecoverPanic(func() { parse("") })
+//	a.NotNil(r).Match(`^parse:`, r.Message())
+func (a *Assert) RecoverPanic(fn func(), msg ...interface{}) *Recovered {
+	var r *Recovered
+	a.assert(func() {
+		r = recoverPanic(fn)
+		if r == nil {
+			a.errorMessage("Panics", nil, nil, "Function did not panic")(msg...)
+		}
+	})
+	return r
+}
+
+// PanicsWithValue asserts that fn panics with a value equal to exp
+// (reflect.DeepEqual).
+func (a *Assert) PanicsWithValue(exp interface{}, fn func(), msg ...interface{}) *Assert {
+	return a.assert(func() {
+		r := recoverPanic(fn)
+		if r == nil {
+			a.errorMessage("PanicsWithValue", exp, nil, "Function did not panic, expected value: %#v", exp)(msg...)
+			return
+		}
+		if !reflect.DeepEqual(exp, r.Value) {
+			a.errorMessage("PanicsWithValue", exp, r.Value, "Panic value mismatch\nExp: %#v\nGot: %#v\n", exp, r.Value)(msg...)
+		}
+	})
+}
+
+// PanicsMatching asserts that fn panics with a message matching the regex
+// pattern. The message is the message of the error or the formatted value.
+func (a *Assert) PanicsMatching(pattern string, fn func(), msg ...interface{}) *Assert {
+	return a.assert(func() {
+		re := regexp.MustCompile(pattern)
+		r := recoverPanic(fn)
+		if r == nil {
+			a.errorMessage("PanicsMatching", pattern, nil, "Function did not panic, expected message: %s", pattern)(msg...)
+			return
+		}
+		if !re.MatchString(r.Message()) {
+			a.errorMessage("PanicsMatching", pattern, r.Value, "Regex (%s) mismatch: %s", pattern, r.Message())(msg...)
+		}
+	})
+}
+
+// PanicsWithType asserts that fn panics with a value of the same type as exp.
+// If exp is a pointer to an interface, the value must implement the interface.
+//
+// Example:
+//
+//	a.PanicsWithType(&MyError{}, fn)
+//	a.PanicsWithType((*runtime.Error)(nil), fn)
+func (a *Assert) PanicsWithType(exp interface{}, fn func(), msg ...interface{}) *Assert {
+	return a.assert(func() {
+		typ := reflect.TypeOf(exp)
+		r := recoverPanic(fn)
+		if r == nil {
+			a.errorMessage("PanicsWithType", typ, nil, "Function did not panic, expected type: %s", typ)(msg...)
+			return
+		}
+		got := reflect.TypeOf(r.Value)
+		if typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Interface {
+			if got == nil || !got.Implements(typ.Elem()) {
+				a.errorMessage("PanicsWithType", typ, got, "Panic type does not implement %s: %v", typ.Elem(), got)(msg...)
+			}
+			return
+		}
+		if got != typ {
+			a.errorMessage("PanicsWithType", typ, got, "Panic type mismatch\nExp: %s\nGot: %v\n", typ, got)(msg...)
+		}
+	})
+}
+
+// NotPanics asserts that fn does not panic. If fn panics, the message shows
+// the value and the stacktrace of the panic.
+func (a *Assert) NotPanics(fn func(), msg ...interface{}) *Assert {
+	return a.assert(func() {
+		if r := recoverPanic(fn); r != nil {
+			a.errorMessage("NotPanics", nil, r.Value, "Unexpected panic: %v\n%s", r.Value, r.Stack)(msg...)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package assert
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+)
+
+func TestAssertPanics(t *testing.T) {
+	isAssert(t, New(t).Panics(func() { panic("boom") }))
+}
+
+func TestAssertRecoverPanic(t *testing.T) {
+	r := New(t).RecoverPanic(func() { panic(errors.New("boom")) })
+	if r == nil || r.Message() != "boom" || len(r.Stack) == 0 {
+		t.Errorf("invalid recovered panic: %#v", r)
+	}
+}
+
+func TestAssertPanicsWithValue(t *testing.T) {
+	isAssert(t, New(t).PanicsWithValue([]int{1}, func() { panic([]int{1}) }))
+}
+
+func TestAssertPanicsMatching(t *testing.T) {
+	isAssert(t, New(t).PanicsMatching(`^bo+m$`, func() { panic("boom") }))
+}
+
+func TestAssertPanicsWithType(t *testing.T) {
+	isAssert(t, New(t).PanicsWithType("", func() { panic("boom") }))
+	isAssert(t, New(t).PanicsWithType((*runtime.Error)(nil), func() {
+		var m map[string]int
+		m["a"] = 1
+	}))
+}
+
+func TestAssertNotPanics(t *testing.T) {
+	isAssert(t, New(t).NotPanics(func() {}))
+}
+
+func TestPanicsFailure(t *testing.T) {
+	fixtures := []func(a *Assert){
+		func(a *Assert) { a.Panics(func() {}) },
+		func(a *Assert) { a.PanicsWithValue(1, func() { panic(2) }) },
+		func(a *Assert) { a.PanicsMatching(`a`, func() { panic("b") }) },
+		func(a *Assert) { a.PanicsWithType(1, func() { panic("b") }) },
+		func(a *Assert) { a.NotPanics(func() { panic(nil) }) },
+	}
+	for i, fn := range fixtures {
+		tb := &fakeTB{TB: t}
+		fn(New(tb))
+		if !tb.failed {
+			t.Errorf("%d: assertion not failed", i)
+		}
+	}
+}
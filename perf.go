@@ -0,0 +1,137 @@
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"testing"
+	"time"
+)
+
+const (
+	// perfWarmup is the number of calls before the measures.
+	perfWarmup = 10
+	// perfRuns is the number of measured calls.
+	perfRuns = 100
+	// perfTrim is the ratio of the slowest calls ignored by MaxDuration.
+	perfTrim = 0.1
+)
+
+// perfClock returns the current time of the duration measures, replaced by
+// the tests.
+var perfClock = time.Now
+
+// MaxAllocs asserts that fn does at most n heap allocations per call on
+// average (see testing.AllocsPerRun).
+//
+// Example:
+//
+//	a.MaxAllocs(0, func() {
+//		buf = strconv.AppendInt(buf[:0], 42, 10)
+//	})
+func (a *Assert) MaxAllocs(n float64, fn func(), msg ...interface{}) *Assert {
+	return a.assert(func() {
+		got := testing.AllocsPerRun(perfRuns, fn)
+		if got > n {
+			d := newDistribution(memSamples(fn, func(m *runtime.MemStats) uint64 { return m.Mallocs }))
+			a.errorMessage("MaxAllocs", n, got, "Exp: <= %v allocs/op\nGot: %v allocs/op\n%s",
+				n, got, d.format(func(v float64) string { return fmt.Sprint(v) }))(msg...)
+		}
+	})
+}
+
+// MaxBytesPerOp asserts that fn allocates at most n bytes on the heap per
+// call on average.
+func (a *Assert) MaxBytesPerOp(n int64, fn func(), msg ...interface{}) *Assert {
+	return a.assert(func() {
+		samples := memSamples(fn, func(m *runtime.MemStats) uint64 { return m.TotalAlloc })
+		got := int64(mean(samples))
+		if got > n {
+			a.errorMessage("MaxBytesPerOp", n, got, "Exp: <= %d B/op\nGot: %d B/op\n%s",
+				n, got, newDistribution(samples).format(func(v float64) string { return fmt.Sprint(v) }))(msg...)
+		}
+	})
+}
+
+// MaxDuration asserts that fn is executed in at most d on average. fn is
+// called several times before the measures to warm up, and the slowest calls
+// are ignored.
+func (a *Assert) MaxDuration(d time.Duration, fn func(), msg ...interface{}) *Assert {
+	return a.assert(func() {
+		samples := durationSamples(fn)
+		got := time.Duration(mean(trim(samples)))
+		if got > d {
+			a.errorMessage("MaxDuration", d, got, "Exp: <= %s/op\nGot: %s/op\n%s",
+				d, got, newDistribution(samples).format(func(v float64) string { return time.Duration(v).String() }))(msg...)
+		}
+	})
+}
+
+// durationSamples returns the duration of each call of fn in nanoseconds.
+func durationSamples(fn func()) []float64 {
+	for i := 0; i < perfWarmup; i++ {
+		fn()
+	}
+	samples := make([]float64, perfRuns)
+	for i := range samples {
+		start := perfClock()
+		fn()
+		samples[i] = float64(perfClock().Sub(start))
+	}
+	return samples
+}
+
+// memSamples returns the difference of the memory statistic stat for each
+// call of fn.
+func memSamples(fn func(), stat func(*runtime.MemStats) uint64) []float64 {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+	for i := 0; i < perfWarmup; i++ {
+		fn()
+	}
+	var before, after runtime.MemStats
+	samples := make([]float64, perfRuns)
+	for i := range samples {
+		runtime.ReadMemStats(&before)
+		fn()
+		runtime.ReadMemStats(&after)
+		samples[i] = float64(stat(&after) - stat(&before))
+	}
+	return samples
+}
+
+// distribution summarizes the measures of the calls.
+type distribution struct {
+	runs             int
+	median, p90, max float64
+}
+
+func newDistribution(samples []float64) distribution {
+	s := append([]float64{}, samples...)
+	sort.Float64s(s)
+	return distribution{
+		runs:   len(s),
+		median: s[len(s)/2],
+		p90:    s[len(s)*9/10],
+		max:    s[len(s)-1],
+	}
+}
+
+func (d distribution) format(unit func(float64) string) string {
+	return fmt.Sprintf("Distribution (%d runs): median %s, p90 %s, max %s\n",
+		d.runs, unit(d.median), unit(d.p90), unit(d.max))
+}
+
+// trim returns the samples without the slowest ones.
+func trim(samples []float64) []float64 {
+	s := append([]float64{}, samples...)
+	sort.Float64s(s)
+	return s[:len(s)-int(float64(len(s))*perfTrim)]
+}
+
+func mean(samples []float64) float64 {
+	sum := 0.0
+	for _, v := range samples {
+		sum += v
+	}
+	return sum / float64(len(samples))
+}
@@ -0,0 +1,69 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var perfSink []byte
+
+func TestMaxAllocs(t *testing.T) {
+	isAssert(t, New(t).MaxAllocs(0, func() {}))
+	tb := &fakeTB{TB: t}
+	New(tb).MaxAllocs(0, func() { perfSink = make([]byte, 64) })
+	if !tb.failed {
+		t.Errorf("allocations not detected")
+	}
+}
+
+func TestMaxBytesPerOp(t *testing.T) {
+	isAssert(t, New(t).MaxBytesPerOp(0, func() {}))
+	b, got := failures(t)
+	b.MaxBytesPerOp(1000, func() { perfSink = make([]byte, 4096) })
+	if len(*got) != 1 || (*got)[0].Got.(int64) < 4096 {
+		t.Fatalf("invalid failures: %v", *got)
+	}
+	if !strings.Contains((*got)[0].Text, "Distribution (100 runs): median ") {
+		t.Errorf("distribution not reported: %s", (*got)[0].Text)
+	}
+}
+
+func TestMaxDuration(t *testing.T) {
+	clock := time.Unix(0, 0)
+	perfClock = func() time.Time { return clock }
+	defer func() { perfClock = time.Now }()
+
+	calls := 0
+	fn := func() {
+		calls++
+		clock = clock.Add(2 * time.Millisecond)
+		if calls%10 == 0 {
+			clock = clock.Add(time.Second) // ignored slowest calls
+		}
+	}
+	isAssert(t, New(t).MaxDuration(2*time.Millisecond, fn))
+
+	b, got := failures(t)
+	b.MaxDuration(time.Millisecond, fn)
+	if len(*got) != 1 || (*got)[0].Got.(time.Duration) != 2*time.Millisecond {
+		t.Fatalf("invalid failures: %v", *got)
+	}
+	if !strings.HasSuffix((*got)[0].Text, "Exp: <= 1ms/op\nGot: 2ms/op\nDistribution (100 runs): median 2ms, p90 1.002s, max 1.002s\n") {
+		t.Errorf("invalid message: %s", (*got)[0].Text)
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	samples := make([]float64, 10)
+	for i := range samples {
+		samples[i] = float64(10 - i)
+	}
+	d := newDistribution(samples)
+	if d.median != 6 || d.p90 != 10 || d.max != 10 || d.runs != 10 {
+		t.Errorf("invalid distribution: %+v", d)
+	}
+	if s := trim(samples); len(s) != 9 || s[8] != 9 {
+		t.Errorf("invalid trim: %v", s)
+	}
+}
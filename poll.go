@@ -0,0 +1,88 @@
+package assert
+
+import (
+	"fmt"
+	"time"
+)
+
+// Eventually asserts that the condition is satisfied before the timeout. The
+// condition is checked every interval.
+//
+// The condition is a func() bool or a func(*Assert): the failures of the
+// nested Assert are collected and only the failures of the last attempt are
+// reported when the timeout expires.
+//
+// Example:
+//
+//	a.Eventually(func(a *T.Assert) {
+//		a.Equal(3, cache.Len()).True(cache.Has("a"))
+//	}, time.Second, 10*time.Millisecond)
+func (a *Assert) Eventually(cond interface{}, timeout, interval time.Duration, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		check := a.condition(cond)
+		start := time.Now()
+		attempts := 0
+		for {
+			attempts++
+			failures, ok := check()
+			if ok {
+				return
+			}
+			elapsed := time.Since(start)
+			if elapsed >= timeout {
+				a.errorMessage("Eventually", nil, nil, "Condition not satisfied after %d attempts in %s\n%s",
+					attempts, elapsed.Round(time.Millisecond), lastAttempt(failures))(msg...)
+				return
+			}
+			if remaining := timeout - elapsed; remaining < interval {
+				time.Sleep(remaining)
+			} else {
+				time.Sleep(interval)
+			}
+		}
+	})
+}
+
+// Consistently asserts that the condition is satisfied during the duration.
+// The condition is checked every interval, see Eventually for the condition.
+func (a *Assert) Consistently(cond interface{}, duration, interval time.Duration, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		check := a.condition(cond)
+		start := time.Now()
+		attempts := 0
+		for {
+			attempts++
+			failures, ok := check()
+			elapsed := time.Since(start)
+			if !ok {
+				a.errorMessage("Consistently", nil, nil, "Condition not satisfied at attempt %d after %s\n%s",
+					attempts, elapsed.Round(time.Millisecond), lastAttempt(failures))(msg...)
+				return
+			}
+			if elapsed >= duration {
+				return
+			}
+			time.Sleep(interval)
+		}
+	})
+}
+
+// condition converts the condition of Eventually and Consistently into a
+// function that returns the failures of an attempt.
+func (a *Assert) condition(cond interface{}) func() ([]Failure, bool) {
+	switch fn := cond.(type) {
+	case func() bool:
+		return func() ([]Failure, bool) { return nil, fn() }
+	case func(*Assert):
+		return func() ([]Failure, bool) { return a.record(fn) }
+	}
+	panic(fmt.Sprintf("condition must be a func() bool or a func(*Assert): %T", cond))
+}
+
+// lastAttempt formats the failures of the last attempt.
+func lastAttempt(failures []Failure) string {
+	if len(failures) == 0 {
+		return ""
+	}
+	return "Last attempt:\n" + failureTexts(failures)
+}
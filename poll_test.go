@@ -0,0 +1,50 @@
+package assert
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAssertEventually(t *testing.T) {
+	var n int32
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		atomic.StoreInt32(&n, 3)
+	}()
+	isAssert(t, New(t).Eventually(func(a *Assert) {
+		a.Equal(int32(3), atomic.LoadInt32(&n))
+	}, time.Second, time.Millisecond))
+}
+
+func TestAssertConsistently(t *testing.T) {
+	isAssert(t, New(t).Consistently(func() bool { return true }, 20*time.Millisecond, 5*time.Millisecond))
+}
+
+func TestEventuallyFailure(t *testing.T) {
+	b, reported := failures(t)
+	n := 0
+	b.SetFatal(true).Eventually(func(a *Assert) {
+		n++
+		a.Equal(0, n, "attempt %d", n).True(false, "not executed in fatal mode")
+	}, 30*time.Millisecond, 10*time.Millisecond)
+	if !b.t.(*fakeTB).failed || len(*reported) == 0 {
+		t.Fatalf("Eventually not failed")
+	}
+	got := (*reported)[len(*reported)-1]
+	if !strings.Contains(got.Text, "Condition not satisfied after") ||
+		!strings.Contains(got.Text, "Last attempt:\n  attempt") ||
+		strings.Contains(got.Text, "not executed") {
+		t.Errorf("invalid message: %s", got.Text)
+	}
+}
+
+func TestConsistentlyFailure(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	n := 0
+	New(tb).Consistently(func() bool { n++; return n < 3 }, time.Second, time.Millisecond)
+	if !tb.failed || n != 3 {
+		t.Errorf("Consistently not failed at the attempt 3: %d", n)
+	}
+}
@@ -0,0 +1,169 @@
+package assert
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+const (
+	// propertyRuns is the number of generated values checked by Property.
+	propertyRuns = 100
+	// maxShrinks is the maximum number of values checked to shrink a
+	// counterexample.
+	maxShrinks = 1000
+)
+
+// Property checks in a subtest that the property fn is satisfied by the
+// values generated by gen. The values are generated from a random seed, set
+// the environment variable GO_ASSERT_SEED to replay a failure.
+//
+// If the property fails, the failing value is shrunk to a minimal
+// counterexample, and its failures are reported with the seed.
+//
+// Example:
+//
+//	T.Property(a, "reverse twice", T.SliceOf(T.Ints(-100, 100), 20),
+//		func(a *T.Assert, s []int) {
+//			a.EqualSlice(s, Reverse(Reverse(s)))
+//		})
+func Property[T any](a *Assert, name string, gen Gen[T], fn func(*Assert, T)) *Assert {
+	return a.It(name, func(a *Assert) {
+		checkProperty(a, propertySeed(), gen, fn)
+	})
+}
+
+// checkProperty checks the property fn with the values generated from the
+// seed.
+func checkProperty[T any](a *Assert, seed int64, gen Gen[T], fn func(*Assert, T)) {
+	r := rand.New(rand.NewSource(seed))
+	check := func(v T) ([]Failure, bool) {
+		var p *Recovered
+		failures, ok := a.record(func(b *Assert) {
+			p = recoverPanic(func() { fn(b, cloneValue(v)) })
+		})
+		if p != nil && p.Value != errAbort {
+			failures = append(failures, Failure{Assertion: "Property", Text: "Panic: " + p.Message() + "\n"})
+			ok = false
+		}
+		return failures, ok
+	}
+	for i := 0; i < propertyRuns; i++ {
+		v := gen.Generate(r, i)
+		failures, ok := check(v)
+		if ok {
+			continue
+		}
+		min, failures, shrinks := shrink(gen, v, failures, check)
+		a.errorMessage("Property", nil, min,
+			"Property failed after %d tests (GO_ASSERT_SEED=%d)\nCounterexample: %#v\nShrunk %d times from: %#v\n%s",
+			i+1, seed, min, shrinks, v, failureTexts(failures))()
+		return
+	}
+}
+
+// cloneValue returns a deep copy of v: fn can modify its value without
+// changing the value shrunk and reported. The unexported fields of the
+// structs are not copied deeply.
+func cloneValue[T any](v T) T {
+	src := reflect.ValueOf(&v).Elem()
+	dst := reflect.New(src.Type()).Elem()
+	deepCopy(dst, src, map[uintptr]reflect.Value{})
+	return dst.Interface().(T)
+}
+
+// deepCopy copies src in dst, seen are the pointers already copied.
+func deepCopy(dst, src reflect.Value, seen map[uintptr]reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		if p, ok := seen[src.Pointer()]; ok {
+			dst.Set(p)
+			return
+		}
+		p := reflect.New(src.Type().Elem())
+		seen[src.Pointer()] = p
+		deepCopy(p.Elem(), src.Elem(), seen)
+		dst.Set(p)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		e := reflect.New(src.Elem().Type()).Elem()
+		deepCopy(e, src.Elem(), seen)
+		dst.Set(e)
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		s := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			deepCopy(s.Index(i), src.Index(i), seen)
+		}
+		dst.Set(s)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			deepCopy(dst.Index(i), src.Index(i), seen)
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		for it := src.MapRange(); it.Next(); {
+			e := reflect.New(src.Type().Elem()).Elem()
+			deepCopy(e, it.Value(), seen)
+			m.SetMapIndex(it.Key(), e)
+		}
+		dst.Set(m)
+	case reflect.Struct:
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				deepCopy(dst.Field(i), src.Field(i), seen)
+			}
+		}
+	default:
+		dst.Set(src)
+	}
+}
+
+// shrink returns the simplest value derived from v that fails, its failures
+// and the number of shrinks.
+func shrink[T any](gen Gen[T], v T, failures []Failure, check func(T) ([]Failure, bool)) (T, []Failure, int) {
+	shrinks, checks := 0, 0
+	for {
+		shrunk := false
+		for _, c := range gen.Shrink(v) {
+			if checks++; checks > maxShrinks {
+				return v, failures, shrinks
+			}
+			if f, ok := check(c); !ok {
+				v, failures, shrunk = c, f, true
+				shrinks++
+				break
+			}
+		}
+		if !shrunk {
+			return v, failures, shrinks
+		}
+	}
+}
+
+// propertySeed returns the seed of GO_ASSERT_SEED or a random seed.
+func propertySeed() int64 {
+	s := os.Getenv("GO_ASSERT_SEED")
+	if s == "" {
+		return time.Now().UnixNano()
+	}
+	seed, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("GO_ASSERT_SEED must be an integer: %s", s))
+	}
+	return seed
+}
@@ -0,0 +1,95 @@
+package assert
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestProperty(t *testing.T) {
+	isAssert(t, Property(New(t), "sort", SliceOf(Ints(-100, 100), 20), func(a *Assert, s []int) {
+		sort.Ints(s)
+		a.True(sort.IntsAreSorted(s))
+	}))
+}
+
+func TestCheckProperty(t *testing.T) {
+	b, got := failures(t)
+	checkProperty(b, 42, SliceOf(Ints(0, 1000), 20), func(a *Assert, s []int) {
+		for _, v := range s {
+			a.True(v < 10)
+		}
+	})
+	if !b.t.(*fakeTB).failed || len(*got) != 1 {
+		t.Fatalf("property not failed: %v", *got)
+	}
+	if !isEqualSlice((*got)[0].Got, []int{10}) {
+		t.Errorf("got: %#v, exp: []int{10}", (*got)[0].Got)
+	}
+	if !strings.Contains((*got)[0].Text, "GO_ASSERT_SEED=42") {
+		t.Errorf("seed not reported: %s", (*got)[0].Text)
+	}
+
+	b, got = failures(t)
+	checkProperty(b, 1, Strings(Lower, 10), func(a *Assert, s string) {
+		if strings.Contains(s, "z") {
+			panic("z")
+		}
+	})
+	if len(*got) != 1 || (*got)[0].Got != "z" || !strings.Contains((*got)[0].Text, "Panic: z") {
+		t.Errorf("invalid failure: %#v", *got)
+	}
+}
+
+func TestCheckPropertyMutation(t *testing.T) {
+	b, got := failures(t)
+	checkProperty(b, 42, SliceOf(Ints(0, 1000), 20), func(a *Assert, s []int) {
+		failed := len(s) > 1 && s[0] > s[1]
+		sort.Ints(s)
+		a.False(failed)
+	})
+	if len(*got) != 1 || !isEqualSlice((*got)[0].Got, []int{1, 0}) {
+		t.Errorf("invalid counterexample: %v", *got)
+	}
+}
+
+type cloneNode struct {
+	Values []int
+	Next   *cloneNode
+	Attrs  map[string][]int
+}
+
+func TestCloneValue(t *testing.T) {
+	n := &cloneNode{Values: []int{1}, Attrs: map[string][]int{"a": {2}}}
+	n.Next = n
+	c := cloneValue(n)
+	c.Values[0], c.Attrs["a"][0] = 3, 4
+	if n.Values[0] != 1 || n.Attrs["a"][0] != 2 || c.Next != c {
+		t.Errorf("invalid copy: %+v, %+v", n, c)
+	}
+	var i interface{} = []int{1}
+	cloneValue(i).([]int)[0] = 2
+	if i.([]int)[0] != 1 {
+		t.Errorf("interface not copied")
+	}
+}
+
+func TestPropertySeed(t *testing.T) {
+	t.Setenv("GO_ASSERT_SEED", "123")
+	if propertySeed() != 123 {
+		t.Errorf("got: %d, exp: 123", propertySeed())
+	}
+}
+
+func isEqualSlice(got interface{}, exp []int) bool {
+	s, ok := got.([]int)
+	if !ok || len(s) != len(exp) {
+		return false
+	}
+	for i := range s {
+		if s[i] != exp[i] {
+			return false
+		}
+	}
+	return true
+}
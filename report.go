@@ -0,0 +1,178 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// Failure describes a failed assertion.
+type Failure struct {
+	// Assertion is the name of the assertion, for example "Equal".
+	Assertion string
+	// Exp and Got are the expected and the got values; nil if not relevant.
+	Exp, Got interface{}
+	// Message is the custom message of the user.
+	Message string
+	// Text is the complete error message showed in the test log.
+	Text string
+	// Frame is the location of the assertion in the test.
+	Frame runtime.Frame
+	// Stack is the stacktrace, only if the stack is enabled.
+	Stack []byte
+}
+
+// Reporter receives the failed assertions.
+//
+// The test is always marked as failed by the Assert after the reporters, so a
+// reporter has not to call t.Fail.
+type Reporter interface {
+	Report(t testing.TB, f Failure)
+}
+
+// ReporterFunc is an adapter to use a function as a Reporter.
+type ReporterFunc func(t testing.TB, f Failure)
+
+// Report calls fn(t, f).
+func (fn ReporterFunc) Report(t testing.TB, f Failure) {
+	fn(t, f)
+}
+
+// LogReporter is the default reporter: the failures are written in the test
+// log and the stacktrace in the standard error.
+//
+// Example with an additional reporter:
+//
+//	a := T.New(t, T.LogReporter{}, myReporter)
+type LogReporter struct{}
+
+// Report writes the failure in the test log.
+func (LogReporter) Report(t testing.TB, f Failure) {
+	t.Helper()
+	if f.Stack != nil {
+		os.Stderr.Write(f.Stack)
+	}
+	t.Logf("%s", f.Text)
+}
+
+// defaultReporters returns the reporters or the LogReporter if there are no
+// reporters.
+func defaultReporters(reporters []Reporter) []Reporter {
+	if len(reporters) == 0 {
+		return []Reporter{LogReporter{}}
+	}
+	return reporters
+}
+
+// pkgDir is the directory of the source files of this package.
+var pkgDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// callerFrame returns the first frame outside of this package, it is the
+// location of the assertion in the test.
+func callerFrame() runtime.Frame {
+	pc := make([]uintptr, 64)
+	n := runtime.Callers(2, pc)
+	frames := runtime.CallersFrames(pc[:n])
+	for {
+		frame, more := frames.Next()
+		if filepath.Dir(frame.File) != pkgDir || strings.HasSuffix(frame.File, "_test.go") {
+			return frame
+		}
+		if !more {
+			return frame
+		}
+	}
+}
+
+// errAbort stops the execution of the assertions of a recorder in fatal mode.
+var errAbort = errors.New("assert: abort")
+
+// recorder is a testing.TB that records the failures instead of failing the
+// test. The errors of the testing.TB methods (Error, Fatal...) are recorded
+// as failures, and a skip stops the execution without failure.
+type recorder struct {
+	testing.TB
+	failed   bool
+	skipped  bool
+	failures []Failure
+}
+
+func (r *recorder) Fail()                    { r.failed = true }
+func (r *recorder) FailNow()                 { r.failed = true; panic(errAbort) }
+func (r *recorder) Failed() bool             { return r.failed }
+func (r *recorder) SkipNow()                 { r.skipped = true; panic(errAbort) }
+func (r *recorder) Skipped() bool            { return r.skipped }
+func (r *recorder) Skip(args ...interface{}) { r.Log(args...); r.SkipNow() }
+
+func (r *recorder) Skipf(format string, args ...interface{}) {
+	r.Logf(format, args...)
+	r.SkipNow()
+}
+
+func (r *recorder) Error(args ...interface{}) {
+	r.fail(fmt.Sprintln(args...))
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.fail(fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatal(args ...interface{}) {
+	r.fail(fmt.Sprintln(args...))
+	r.FailNow()
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.fail(fmt.Sprintf(format, args...))
+	r.FailNow()
+}
+
+// fail records a failure of the testing.TB methods with the text.
+func (r *recorder) fail(text string) {
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	r.failures = append(r.failures, Failure{Text: text, Frame: callerFrame()})
+	r.failed = true
+}
+
+// Report records the failure.
+func (r *recorder) Report(_ testing.TB, f Failure) {
+	r.failures = append(r.failures, f)
+}
+
+// record executes fn with an Assert that records the failures instead of
+// reporting them. It returns the failures and false if fn failed.
+func (a *Assert) record(fn func(*Assert)) ([]Failure, bool) {
+	r := &recorder{TB: a.t}
+	b := a.clone(r)
+	b.cfg.fatal = a.conf().fatal
+	b.cfg.reporters = []Reporter{r}
+	func() {
+		defer func() {
+			if v := recover(); v != nil && v != errAbort {
+				panic(v)
+			}
+		}()
+		fn(b)
+	}()
+	return r.failures, !r.failed
+}
+
+// failureTexts returns the messages of the failures, indented.
+func failureTexts(failures []Failure) string {
+	var buf strings.Builder
+	for _, f := range failures {
+		for _, line := range strings.Split(strings.TrimSuffix(f.Text, "\n"), "\n") {
+			buf.WriteString("  " + line + "\n")
+		}
+	}
+	return buf.String()
+}
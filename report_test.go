@@ -0,0 +1,90 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+// fakeTB records the failures without failing the test.
+type fakeTB struct {
+	testing.TB
+	failed bool
+	logs   []string
+}
+
+func (f *fakeTB) Fail()                                   { f.failed = true }
+func (f *fakeTB) FailNow()                                { f.failed = true }
+func (f *fakeTB) Helper()                                 {}
+func (f *fakeTB) Logf(format string, args ...interface{}) { f.logs = append(f.logs, format) }
+
+// failures returns an Assert of a fakeTB wrapping tb that records the
+// failures.
+func failures(tb testing.TB) (*Assert, *[]Failure) {
+	var got []Failure
+	r := ReporterFunc(func(_ testing.TB, f Failure) { got = append(got, f) })
+	return New(&fakeTB{TB: tb}, r), &got
+}
+
+func TestReporter(t *testing.T) {
+	b, got := failures(t)
+	isAssert(t, b.Equal(3, 4, "my %s", "message").Equal(1, 1))
+	if !b.t.(*fakeTB).failed {
+		t.Errorf("test not failed")
+	}
+	if len(*got) != 1 {
+		t.Fatalf("got: %d failures, exp: 1", len(*got))
+	}
+	f := (*got)[0]
+	if f.Assertion != "Equal" || f.Exp != 3 || f.Got != 4 || f.Message != "my message" {
+		t.Errorf("invalid failure: %#v", f)
+	}
+	if !strings.HasSuffix(f.Frame.File, "report_test.go") {
+		t.Errorf("invalid frame: %s", f.Frame.File)
+	}
+	if f.Text != "my message\nExp: 3\nGot: 4\n" {
+		t.Errorf("invalid text: %q", f.Text)
+	}
+}
+
+func TestLogReporter(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	NewCustom(tb, true, false).True(false)
+	if !tb.failed || len(tb.logs) != 1 {
+		t.Errorf("LogReporter failed: %#v", tb)
+	}
+}
+
+func TestSetReporters(t *testing.T) {
+	n := 0
+	r := ReporterFunc(func(testing.TB, Failure) { n++ })
+	tb := &fakeTB{TB: t}
+	New(tb).SetReporters(LogReporter{}, r).False(true)
+	if n != 1 || len(tb.logs) != 1 {
+		t.Errorf("got: %d, %d, exp: 1, 1", n, len(tb.logs))
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	a := New(t)
+	failures, ok := a.record(func(a *Assert) {
+		a.t.Errorf("error %d", 1)
+		a.t.Fatal("fatal")
+		a.t.Error("not executed")
+	})
+	if ok || len(failures) != 2 || failures[0].Text != "error 1\n" || failures[1].Text != "fatal\n" {
+		t.Errorf("invalid failures: %v, %v", failures, ok)
+	}
+	failures, ok = a.record(func(a *Assert) {
+		a.t.Skip("skipped")
+		a.t.Error("not executed")
+	})
+	if !ok || len(failures) != 0 {
+		t.Errorf("invalid skip: %v, %v", failures, ok)
+	}
+	failures, ok = a.record(func(a *Assert) {
+		a.It("sub test", func(*Assert) {})
+	})
+	if ok || len(failures) != 1 || !strings.Contains(failures[0].Text, "subtests are not supported") {
+		t.Errorf("invalid subtest: %v, %v", failures, ok)
+	}
+}
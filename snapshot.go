@@ -0,0 +1,356 @@
+package assert
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// snapshotDir is the directory of the snapshot files.
+const snapshotDir = "testdata/__snapshots__"
+
+// snapshotHeader separates the snapshots of a file.
+var snapshotHeader = regexp.MustCompile(`^--- snapshot (\d+) ---$`)
+
+// snapshots records the snapshots of the current execution.
+var snapshots = struct {
+	sync.Mutex
+	// counters is the number of snapshots of each test.
+	counters map[string]int
+	// entries are the snapshots of each file written in update mode.
+	entries map[string][]string
+	// used are the snapshot files used by the tests.
+	used map[string]bool
+	// roots are the top level tests checked by obsoleteSnapshots.
+	roots map[testing.TB]bool
+	// skipped are the top level tests with a skipped subtest.
+	skipped map[string]bool
+}{
+	counters: map[string]int{},
+	entries:  map[string][]string{},
+	used:     map[string]bool{},
+	roots:    map[testing.TB]bool{},
+	skipped:  map[string]bool{},
+}
+
+// Snapshot compares the value to the snapshot stored in the file
+// testdata/__snapshots__/<Test>/<subtest>.snap. The value is serialized in a
+// stable and readable form.
+//
+// The snapshot is created if it does not exist, and it is rewritten in update
+// mode (see EqualFile). A test can have several snapshots, they are stored in
+// the same file in the order of the calls.
+//
+// Example:
+//
+//	T.New(t).It("sub test", func(a *T.Assert) {
+//		a.Snapshot(user).Snapshot(user.Roles, "roles")
+//	})
+func (a *Assert) Snapshot(value interface{}, msg ...interface{}) *Assert {
+	return a.assert(func() {
+		file := snapshotFile(a.t.Name())
+		got := serialize(value)
+
+		name := a.t.Name()
+		snapshots.Lock()
+		snapshots.counters[name]++
+		index := snapshots.counters[name]
+		snapshots.used[file] = true
+		snapshots.Unlock()
+
+		stored := readSnapshots(file)
+		if index == 1 {
+			// The counter is reset for the next execution of the test (go
+			// test -count=n).
+			a.t.Cleanup(func() {
+				snapshots.Lock()
+				n := snapshots.counters[name]
+				delete(snapshots.counters, name)
+				delete(snapshots.entries, file)
+				snapshots.Unlock()
+				for i := n + 1; i <= len(stored); i++ {
+					a.t.Logf("Obsolete snapshot %d in %s", i, file)
+				}
+			})
+		}
+
+		if updateMode() || index > len(stored) {
+			writeSnapshot(a.t, file, index, got, stored)
+			return
+		}
+		if exp := stored[index-1]; exp != got {
+			a.errorMessage("Snapshot", exp, got, "Snapshot %d of %s\n%s", index, file, a.equalMessage(exp, got))(msg...)
+		}
+	})
+}
+
+// snapshotFile returns the file of the snapshots of the test.
+func snapshotFile(name string) string {
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 1 {
+		parts = append(parts, parts[0])
+	}
+	return filepath.Join(snapshotDir, parts[0], filepath.FromSlash(parts[1])+".snap")
+}
+
+// readSnapshots returns the snapshots of the file, or nil if the file does
+// not exist.
+func readSnapshots(file string) []string {
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		panic(err)
+	}
+	var (
+		res   []string
+		lines []string
+		found bool
+	)
+	flush := func() {
+		if found {
+			res = append(res, strings.Join(lines, "\n"))
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+		if snapshotHeader.MatchString(line) {
+			flush()
+			found, lines = true, nil
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return res
+}
+
+// writeSnapshot writes the snapshot index of the file. In update mode, the
+// file contains only the snapshots of the current execution.
+func writeSnapshot(t testing.TB, file string, index int, got string, stored []string) {
+	snapshots.Lock()
+	entries := snapshots.entries[file]
+	if len(entries) < index-1 {
+		// Snapshots not updated of a previous execution.
+		entries = append(entries, stored[len(entries):index-1]...)
+	}
+	entries = append(entries[:index-1], got)
+	snapshots.entries[file] = entries
+	snapshots.Unlock()
+
+	var buf strings.Builder
+	for i, e := range entries {
+		fmt.Fprintf(&buf, "--- snapshot %d ---\n%s\n", i+1, e)
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(file, []byte(buf.String()), 0644); err != nil {
+		panic(err)
+	}
+	t.Logf("Snapshot %d written: %s", index, file)
+}
+
+// obsoleteSnapshots reports the snapshot files of the top level test t that
+// are not used at the end of the test. In update mode, they are removed if all
+// the tests are executed: the tests are not filtered (see filtered) and no
+// subtest of t is skipped.
+func obsoleteSnapshots(t testing.TB) {
+	dir := filepath.Join(snapshotDir, t.Name())
+	if strings.Contains(t.Name(), "/") || !isDir(dir) {
+		return
+	}
+	snapshots.Lock()
+	defer snapshots.Unlock()
+	if snapshots.roots[t] {
+		return
+	}
+	snapshots.roots[t] = true
+	delete(snapshots.skipped, t.Name())
+	t.Cleanup(func() {
+		snapshots.Lock()
+		delete(snapshots.roots, t)
+		skipped := snapshots.skipped[t.Name()]
+		delete(snapshots.skipped, t.Name())
+		snapshots.Unlock()
+		if t.Skipped() {
+			return
+		}
+		filepath.Walk(dir, func(pth string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() || filepath.Ext(pth) != ".snap" {
+				return err
+			}
+			snapshots.Lock()
+			used := snapshots.used[pth]
+			snapshots.Unlock()
+			if used {
+				return nil
+			}
+			if updateMode() && !skipped && !filtered() {
+				t.Logf("Obsolete snapshot removed: %s", pth)
+				return os.Remove(pth)
+			}
+			t.Logf("Obsolete snapshot: %s", pth)
+			return nil
+		})
+	})
+}
+
+// filtered returns true if the tests are filtered with -run or -skip, or if
+// the long tests are skipped with -short.
+func filtered() bool {
+	for _, name := range []string{"test.run", "test.skip"} {
+		if f := flag.Lookup(name); f != nil && f.Value.String() != "" {
+			return true
+		}
+	}
+	return testing.Short()
+}
+
+// recordSkip records the top level test of the subtest executed by fn if the
+// subtest is skipped: its obsolete snapshots are not removed.
+func recordSkip(fn func(testing.TB)) func(testing.TB) {
+	return func(t testing.TB) {
+		t.Cleanup(func() {
+			if t.Skipped() {
+				snapshots.Lock()
+				snapshots.skipped[rootName(t)] = true
+				snapshots.Unlock()
+			}
+		})
+		fn(t)
+	}
+}
+
+// serialize formats a value in a stable and readable form: the struct fields
+// and the map entries are showed one per line, the map keys are sorted.
+func serialize(value interface{}) string {
+	var buf strings.Builder
+	s := &serializer{&buf, map[uintptr]bool{}}
+	s.write(reflect.ValueOf(value), 0)
+	return buf.String()
+}
+
+type serializer struct {
+	buf     *strings.Builder
+	visited map[uintptr]bool
+}
+
+func (s *serializer) indent(n int) {
+	s.buf.WriteString(strings.Repeat("  ", n))
+}
+
+func (s *serializer) write(v reflect.Value, depth int) {
+	if !v.IsValid() {
+		s.buf.WriteString("nil")
+		return
+	}
+	if v.Kind() == reflect.Struct && v.CanInterface() {
+		if str, ok := v.Interface().(fmt.Stringer); ok {
+			fmt.Fprintf(s.buf, "%s(%s)", v.Type(), strconv.Quote(str.String()))
+			return
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			fmt.Fprintf(s.buf, "(%s)(nil)", v.Type())
+			return
+		}
+		if s.visited[v.Pointer()] {
+			fmt.Fprintf(s.buf, "<cycle %s>", v.Type())
+			return
+		}
+		s.visited[v.Pointer()] = true
+		defer delete(s.visited, v.Pointer())
+		s.buf.WriteString("&")
+		s.write(v.Elem(), depth)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			s.buf.WriteString("nil")
+			return
+		}
+		s.write(v.Elem(), depth)
+
+	case reflect.Struct:
+		fmt.Fprintf(s.buf, "%s{", v.Type())
+		if v.NumField() == 0 {
+			s.buf.WriteString("}")
+			return
+		}
+		s.buf.WriteString("\n")
+		for i := 0; i < v.NumField(); i++ {
+			s.indent(depth + 1)
+			s.buf.WriteString(v.Type().Field(i).Name + ": ")
+			s.write(v.Field(i), depth+1)
+			s.buf.WriteString(",\n")
+		}
+		s.indent(depth)
+		s.buf.WriteString("}")
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			fmt.Fprintf(s.buf, "%s(nil)", v.Type())
+			return
+		}
+		fmt.Fprintf(s.buf, "%s{", v.Type())
+		if v.Len() == 0 {
+			s.buf.WriteString("}")
+			return
+		}
+		s.buf.WriteString("\n")
+		for i := 0; i < v.Len(); i++ {
+			s.indent(depth + 1)
+			s.write(v.Index(i), depth+1)
+			s.buf.WriteString(",\n")
+		}
+		s.indent(depth)
+		s.buf.WriteString("}")
+
+	case reflect.Map:
+		if v.IsNil() {
+			fmt.Fprintf(s.buf, "%s(nil)", v.Type())
+			return
+		}
+		fmt.Fprintf(s.buf, "%s{", v.Type())
+		if v.Len() == 0 {
+			s.buf.WriteString("}")
+			return
+		}
+		s.buf.WriteString("\n")
+		keys := v.MapKeys()
+		sortValues(keys)
+		for _, k := range keys {
+			s.indent(depth + 1)
+			s.write(k, depth+1)
+			s.buf.WriteString(": ")
+			s.write(v.MapIndex(k), depth+1)
+			s.buf.WriteString(",\n")
+		}
+		s.indent(depth)
+		s.buf.WriteString("}")
+
+	case reflect.String:
+		s.buf.WriteString(strconv.Quote(v.String()))
+
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if v.IsNil() {
+			fmt.Fprintf(s.buf, "(%s)(nil)", v.Type())
+		} else {
+			fmt.Fprintf(s.buf, "(%s)(not nil)", v.Type())
+		}
+
+	default:
+		fmt.Fprintf(s.buf, "%#v", v)
+	}
+}
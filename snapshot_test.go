@@ -0,0 +1,166 @@
+package assert
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type snapUser struct {
+	Name  string
+	Roles map[string]int
+	Next  *snapUser
+}
+
+func TestSerialize(t *testing.T) {
+	u := &snapUser{Name: "bob", Roles: map[string]int{"b": 2, "a": 1}}
+	u.Next = u
+	exp := `&assert.snapUser{
+  Name: "bob",
+  Roles: map[string]int{
+    "a": 1,
+    "b": 2,
+  },
+  Next: <cycle *assert.snapUser>,
+}`
+	if got := serialize(u); got != exp {
+		t.Errorf("got:\n%s\nexp:\n%s", got, exp)
+	}
+	if got := serialize([]int(nil)); got != "[]int(nil)" {
+		t.Errorf("got: %s", got)
+	}
+}
+
+func TestSnapshotFile(t *testing.T) {
+	if f := snapshotFile("TestA/sub_1"); f != filepath.Join(snapshotDir, "TestA", "sub_1.snap") {
+		t.Errorf("got: %s", f)
+	}
+	if f := snapshotFile("TestA"); f != filepath.Join(snapshotDir, "TestA", "TestA.snap") {
+		t.Errorf("got: %s", f)
+	}
+}
+
+func TestAssertSnapshot(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpDir(func(dir string) {
+		if err := os.Chdir(dir); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(wd)
+
+		isAssert(t, New(t).Snapshot("one").Snapshot([]int{1, 2}))
+		file := snapshotFile(t.Name())
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "--- snapshot 1 ---\n\"one\"\n--- snapshot 2 ---\n[]int{\n  1,\n  2,\n}\n" {
+			t.Errorf("invalid snapshot file: %q", data)
+		}
+		if s := readSnapshots(file); len(s) != 2 || s[0] != `"one"` {
+			t.Errorf("invalid snapshots: %q", s)
+		}
+
+		snapshots.Lock()
+		snapshots.counters[t.Name()] = 0
+		snapshots.Unlock()
+		tb := &fakeTB{TB: t}
+		New(tb).Snapshot("one").Snapshot([]int{1, 3})
+		if !tb.failed {
+			t.Errorf("snapshot mismatch not detected")
+		}
+	})
+}
+
+// runTB is a test executed several times (go test -count=n): the cleanup
+// functions are executed by end.
+type runTB struct {
+	*fakeTB
+	name     string
+	skipped  bool
+	cleanups []func()
+}
+
+func (r *runTB) Name() string                { return r.name }
+func (r *runTB) Skipped() bool               { return r.skipped }
+func (r *runTB) Cleanup(fn func())           { r.cleanups = append(r.cleanups, fn) }
+func (r *runTB) Logf(string, ...interface{}) {}
+
+func (r *runTB) end() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+func TestSnapshotCount(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpDir(func(dir string) {
+		if err := os.Chdir(dir); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(wd)
+
+		name := t.Name() + "/count"
+		first := &runTB{fakeTB: &fakeTB{TB: t}, name: name}
+		New(first).Snapshot("one")
+		first.end()
+		second := &runTB{fakeTB: &fakeTB{TB: t}, name: name}
+		New(second).Snapshot("two")
+		second.end()
+		if first.failed || !second.failed {
+			t.Errorf("got: %v, %v, exp: false, true", first.failed, second.failed)
+		}
+
+		root := &runTB{fakeTB: &fakeTB{TB: t}, name: "TestSnapshotCount"}
+		New(root)
+		New(root)
+		if len(root.cleanups) != 1 {
+			t.Errorf("got: %d cleanups, exp: 1", len(root.cleanups))
+		}
+		root.end()
+	})
+}
+
+func TestObsoleteSnapshots(t *testing.T) {
+	t.Setenv("GO_ASSERT_UPDATE", "1")
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpDir(func(dir string) {
+		if err := os.Chdir(dir); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(wd)
+
+		file := snapshotFile("TestObsolete/old")
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(file, []byte("--- snapshot 1 ---\n1\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		for _, skip := range []bool{true, false} {
+			root := &runTB{fakeTB: &fakeTB{TB: t}, name: "TestObsolete"}
+			obsoleteSnapshots(root)
+			sub := &runTB{fakeTB: &fakeTB{TB: t}, name: "TestObsolete/sub", skipped: skip}
+			recordSkip(func(testing.TB) {})(sub)
+			sub.end()
+			root.end()
+			_, err := os.Stat(file)
+			if skip && err != nil {
+				t.Errorf("snapshot removed with a skipped subtest: %v", err)
+			}
+			if !skip && !filtered() && !os.IsNotExist(err) {
+				t.Errorf("obsolete snapshot not removed: %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package assert
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TableCase contains the options of a test case. Embed it in the test case
+// struct to skip or focus the case in Table:
+//
+//	type testCase struct {
+//		T.TableCase
+//		in, out string
+//	}
+//
+//	cases := []testCase{
+//		{in: "a", out: "A"},
+//		{TableCase: T.TableCase{Skip: true}, in: "b", out: "B"},
+//	}
+type TableCase struct {
+	// Skip skips the test case.
+	Skip bool
+	// Focus executes only the focused test cases of the table.
+	Focus bool
+}
+
+func (c TableCase) tableCase() TableCase {
+	return c
+}
+
+// tableCaser is implemented by the test cases embedding a TableCase.
+type tableCaser interface {
+	tableCase() TableCase
+}
+
+// caseOptions returns the options of the test case c.
+func caseOptions(c interface{}) TableCase {
+	if v, ok := c.(tableCaser); ok {
+		return v.tableCase()
+	}
+	return TableCase{}
+}
+
+// Table executes fn in a subtest for each test case. The name of the subtest
+// is returned by name, if name is nil the subtest is named "case <index>".
+//
+// The test cases embedding a TableCase can be skipped or focused. If at least
+// one test case is focused, the other test cases are skipped. Like FIt, the
+// test fails if a test case is focused on CI.
+//
+// At the end of the test, the failed test cases are summarized in the test
+// log.
+//
+// Example:
+//
+//	T.Table(a, cases, func(c testCase) string { return c.in },
+//		func(a *T.Assert, c testCase) {
+//			a.Equal(c.out, strings.ToUpper(c.in))
+//		})
+func Table[C any](a *Assert, cases []C, name func(C) string, fn func(*Assert, C)) *Assert {
+	return table(a, false, cases, name, fn)
+}
+
+// TableParallel is similar to Table but the test cases are executed in
+// parallel (see ItParallel).
+func TableParallel[C any](a *Assert, cases []C, name func(C) string, fn func(*Assert, C)) *Assert {
+	return table(a, true, cases, name, fn)
+}
+
+func table[C any](a *Assert, parallel bool, cases []C, name func(C) string, fn func(*Assert, C)) *Assert {
+	focus := false
+	for _, c := range cases {
+		focus = focus || caseOptions(c).Focus
+	}
+	s := &tableSummary{total: len(cases)}
+	t := a.t
+	t.Cleanup(func() {
+		if text := s.String(); text != "" {
+			t.Logf("%s", text)
+		}
+	})
+	for i, c := range cases {
+		i, c := i, c
+		title := fmt.Sprintf("case %d", i)
+		if name != nil {
+			title = name(c)
+		}
+		if caseOptions(c).Focus && onCI() {
+			a.errorMessage("Table", nil, nil, "Focused test case %q on CI\n", title)()
+		}
+		a.subtest(title, parallel, func(a *Assert) {
+			defer func() {
+				if a.t.Failed() {
+					s.add(i, title)
+				}
+			}()
+			switch opts := caseOptions(c); {
+			case opts.Skip:
+				a.Skip("skipped test case")
+			case focus && !opts.Focus:
+				a.Skip("not focused test case")
+			}
+			fn(a, c)
+		})
+	}
+	return a
+}
+
+// tableSummary collects the failed test cases of a table.
+type tableSummary struct {
+	mu     sync.Mutex
+	total  int
+	failed []tableFailure
+}
+
+type tableFailure struct {
+	index int
+	name  string
+}
+
+func (s *tableSummary) add(index int, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed = append(s.failed, tableFailure{index, name})
+}
+
+// String returns the summary of the failed test cases or an empty string if
+// all the test cases passed.
+func (s *tableSummary) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.failed) == 0 {
+		return ""
+	}
+	sort.Slice(s.failed, func(i, j int) bool { return s.failed[i].index < s.failed[j].index })
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d/%d test cases failed:\n", len(s.failed), s.total)
+	for _, f := range s.failed {
+		fmt.Fprintf(&b, "  %4d  %s\n", f.index, f.name)
+	}
+	return b.String()
+}
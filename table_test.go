@@ -0,0 +1,84 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type tableTestCase struct {
+	TableCase
+	in, out string
+}
+
+func TestTable(t *testing.T) {
+	t.Setenv("CI", "")
+	t.Setenv("GO_ASSERT_CI", "")
+	var mu sync.Mutex
+	var got []string
+	run := func(a *Assert, c tableTestCase) {
+		a.Equal(c.out, strings.ToUpper(c.in))
+		mu.Lock()
+		got = append(got, c.in)
+		mu.Unlock()
+	}
+	cases := []tableTestCase{
+		{in: "a", out: "A"},
+		{TableCase: TableCase{Skip: true}, in: "b", out: "B"},
+		{in: "c", out: "C"},
+	}
+	isAssert(t, Table(New(t), cases, func(c tableTestCase) string { return c.in }, run))
+	if strings.Join(got, "") != "ac" {
+		t.Errorf("got: %v, exp: [a c]", got)
+	}
+
+	got = nil
+	cases[2].Focus = true
+	isAssert(t, Table(New(t), cases, nil, run))
+	if strings.Join(got, "") != "c" {
+		t.Errorf("got: %v, exp: [c]", got)
+	}
+
+	got = nil
+	cases[2].Focus = false
+	New(t).It("parallel", func(a *Assert) {
+		isAssert(t, TableParallel(a, cases, nil, run))
+	})
+	if len(got) != 2 {
+		t.Errorf("got: %v, exp: 2 cases", got)
+	}
+}
+
+// noRunTB records the subtests not supported instead of failing the test.
+type noRunTB struct {
+	testing.TB
+	fatals []string
+}
+
+func (n *noRunTB) Fatalf(format string, args ...interface{}) {
+	n.fatals = append(n.fatals, fmt.Sprintf(format, args...))
+}
+
+func TestTableFocusCI(t *testing.T) {
+	t.Setenv("CI", "true")
+	b, got := failures(&noRunTB{TB: t})
+	cases := []tableTestCase{{in: "a"}, {TableCase: TableCase{Focus: true}, in: "b"}}
+	Table(b, cases, func(c tableTestCase) string { return c.in }, func(*Assert, tableTestCase) {})
+	if len(*got) != 1 || !strings.HasSuffix((*got)[0].Text, "Focused test case \"b\" on CI\n") {
+		t.Errorf("focused test case allowed on CI: %v", *got)
+	}
+}
+
+func TestTableSummary(t *testing.T) {
+	s := &tableSummary{total: 4}
+	if s.String() != "" {
+		t.Errorf("invalid summary: %q", s.String())
+	}
+	s.add(3, "case d")
+	s.add(1, "case b")
+	exp := "2/4 test cases failed:\n     1  case b\n     3  case d\n"
+	if s.String() != exp {
+		t.Errorf("got: %q, exp: %q", s.String(), exp)
+	}
+}
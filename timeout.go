@@ -0,0 +1,165 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// runTimeout executes fn in a new goroutine and fails the test if fn is not
+// completed before the timeout of the Assert. The goroutines created by the
+// subtest are showed on timeout.
+//
+// The failures and the logs of fn are recorded and replayed on the goroutine
+// of the test, because testing.T.FailNow must be called by this goroutine.
+// fn cannot be stopped, it continues in background after the timeout but its
+// failures and its logs are ignored.
+func (a *Assert) runTimeout(fn func(*Assert)) {
+	ctx, cancel := context.WithCancel(a.Context())
+	defer cancel()
+	a.ctx = ctx
+	a.expired = new(int32)
+	tb := &timeoutTB{TB: a.t}
+	b := a.dup()
+	b.t = tb
+	before := takeSnapshot()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(b)
+	}()
+
+	timeout := a.conf().timeout
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		tb.replay()
+	case <-timer.C:
+		blocked := before.newGoroutines(nil)
+		tb.expire()
+		atomic.StoreInt32(a.expired, 1)
+		cancel()
+		c := a.dup()
+		c.expired = nil
+		c.SetFatal(true).errorMessage("Timeout", timeout, nil, "Subtest timed out after %s, %d goroutines blocked:\n%s\n",
+			timeout, len(blocked), stacks(blocked))()
+	}
+}
+
+// timeoutTB is the testing.TB of a function executed by runTimeout: the
+// failures, the skips and the logs are recorded and replayed by the
+// goroutine of the test. FailNow and SkipNow stop the goroutine of the
+// function. The events after the timeout are ignored.
+type timeoutTB struct {
+	testing.TB
+	mu      sync.Mutex
+	expired bool
+	events  []func()
+	failed  bool
+	fatal   bool
+	skipped bool
+}
+
+// event records fn, replayed on the goroutine of the test.
+func (r *timeoutTB) event(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.expired {
+		r.events = append(r.events, fn)
+	}
+}
+
+// replay executes the events on the goroutine of the test.
+func (r *timeoutTB) replay() {
+	r.mu.Lock()
+	events, fatal, skipped := r.events, r.fatal, r.skipped
+	r.events = nil
+	r.mu.Unlock()
+	r.TB.Helper()
+	for _, e := range events {
+		e()
+	}
+	switch {
+	case fatal:
+		r.TB.FailNow()
+	case skipped:
+		r.TB.SkipNow()
+	}
+}
+
+// expire ignores the next events.
+func (r *timeoutTB) expire() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expired = true
+	r.events = nil
+}
+
+func (r *timeoutTB) Log(args ...interface{}) {
+	s := fmt.Sprintln(args...)
+	r.event(func() { r.TB.Logf("%s", s) })
+}
+
+func (r *timeoutTB) Logf(format string, args ...interface{}) {
+	s := fmt.Sprintf(format, args...)
+	r.event(func() { r.TB.Logf("%s", s) })
+}
+
+func (r *timeoutTB) Fail() {
+	r.mu.Lock()
+	r.failed = true
+	r.mu.Unlock()
+	r.event(r.TB.Fail)
+}
+
+func (r *timeoutTB) Failed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.failed || r.TB.Failed()
+}
+
+func (r *timeoutTB) FailNow() {
+	r.Fail()
+	r.mu.Lock()
+	r.fatal = true
+	r.mu.Unlock()
+	runtime.Goexit()
+}
+
+func (r *timeoutTB) Error(args ...interface{}) { r.Log(args...); r.Fail() }
+func (r *timeoutTB) Fatal(args ...interface{}) { r.Log(args...); r.FailNow() }
+
+func (r *timeoutTB) Errorf(format string, args ...interface{}) {
+	r.Logf(format, args...)
+	r.Fail()
+}
+
+func (r *timeoutTB) Fatalf(format string, args ...interface{}) {
+	r.Logf(format, args...)
+	r.FailNow()
+}
+
+func (r *timeoutTB) SkipNow() {
+	r.mu.Lock()
+	r.skipped = true
+	r.mu.Unlock()
+	runtime.Goexit()
+}
+
+func (r *timeoutTB) Skipped() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.skipped || r.TB.Skipped()
+}
+
+func (r *timeoutTB) Skip(args ...interface{}) { r.Log(args...); r.SkipNow() }
+
+func (r *timeoutTB) Skipf(format string, args ...interface{}) {
+	r.Logf(format, args...)
+	r.SkipNow()
+}
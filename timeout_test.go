@@ -0,0 +1,85 @@
+package assert
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAssertItTimeout(t *testing.T) {
+	isAssert(t, New(t).ItTimeout("timeout", time.Second, func(a *Assert) {
+		select {
+		case <-a.Context().Done():
+			t.Errorf("context canceled")
+		default:
+		}
+	}))
+}
+
+func TestAssertSetTimeout(t *testing.T) {
+	isAssert(t, New(t).SetTimeout(time.Second).ItTmp("tmp", func(a *Assert, dir string) {
+		if a.Context().Done() == nil {
+			t.Errorf("context without timeout")
+		}
+	}))
+}
+
+func TestRunTimeout(t *testing.T) {
+	b, got := failures(t)
+	a := b.SetTimeout(20 * time.Millisecond)
+	done := make(chan bool)
+	a.runTimeout(func(a *Assert) {
+		<-a.Context().Done()
+		a.True(false, "ignored failure")
+		close(done)
+	})
+	<-done
+	if !b.t.(*fakeTB).failed {
+		t.Fatalf("timeout not detected")
+	}
+	if len(*got) != 1 || !strings.Contains((*got)[0].Text, "Subtest timed out after 20ms") ||
+		!strings.Contains((*got)[0].Text, "TestRunTimeout") {
+		t.Errorf("invalid failures: %#v", *got)
+	}
+}
+
+func TestRunTimeoutFatal(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	reached := false
+	New(tb).SetFatal(true).SetTimeout(time.Second).runTimeout(func(a *Assert) {
+		a.True(false, "fatal failure")
+		reached = true
+	})
+	if !tb.failed || reached {
+		t.Errorf("got: %v, %v, exp: true, false", tb.failed, reached)
+	}
+	if len(tb.logs) != 1 {
+		t.Errorf("got: %d logs, exp: 1", len(tb.logs))
+	}
+}
+
+func TestCaptureTimeout(t *testing.T) {
+	stdout := os.Stdout
+	release := make(chan bool)
+	defer close(release)
+	tb := &fakeTB{TB: t}
+	New(tb).SetTimeout(20 * time.Millisecond).runTimeout(func(a *Assert) {
+		captureMu.Lock()
+		captureOutput(a.Context(), func() { <-release })
+	})
+	if !tb.failed {
+		t.Fatalf("timeout not detected")
+	}
+	deadline := time.Now().Add(time.Second)
+	for !captureMu.TryLock() {
+		if time.Now().After(deadline) {
+			t.Fatalf("capture not released")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	defer captureMu.Unlock()
+	if os.Stdout != stdout {
+		t.Errorf("standard output not restored")
+	}
+}
@@ -10,17 +10,28 @@ func errorMessage(s string, msg ...interface{}) string {
 	if len(msg) == 0 {
 		return fmt.Sprintf("Error:\n%s", s)
 	}
+	return fmt.Sprintf("%s\n%s", userMessage(msg...), s)
+}
+
+// userMessage formats the custom message of the user.
+func userMessage(msg ...interface{}) string {
+	if len(msg) == 0 {
+		return ""
+	}
 	f, ok := msg[0].(string)
 	if !ok {
 		panic(fmt.Sprintf("first message should be a string: %#v", msg[0]))
 	}
 	if len(msg) == 1 {
-		return fmt.Sprintf("%s\n%s", f, s)
+		return f
 	}
-	if len(msg) > 1 {
-		return fmt.Sprintf("%s\n%s", fmt.Sprintf(f, msg[1:]...), s)
-	}
-	return s
+	return fmt.Sprintf(f, msg[1:]...)
+}
+
+// envBool returns true if the environment variable is true, t or 1.
+func envBool(name string) bool {
+	v := os.Getenv(name)
+	return v == "true" || v == "t" || v == "1"
 }
 
 // isDir returns true if the path is an existing directory.